@@ -56,6 +56,7 @@ type PullRequest struct {
 	Assigned        []string `json:"assigned_reviewers"`
 	CreatedAt       *string  `json:"createdAt,omitempty"`
 	MergedAt        *string  `json:"mergedAt,omitempty"`
+	MergedBy        *string  `json:"merged_by,omitempty"`
 	_               struct{} // no unknown fields check
 }
 
@@ -82,6 +83,12 @@ var httpClient = &http.Client{
 func postJSON(t *testing.T, path string, body any) *http.Response {
 	t.Helper()
 
+	return postJSONWithHeaders(t, path, body, nil)
+}
+
+func postJSONWithHeaders(t *testing.T, path string, body any, headers map[string]string) *http.Response {
+	t.Helper()
+
 	data, err := json.Marshal(body)
 	if err != nil {
 		t.Fatalf("marshal body: %v", err)
@@ -94,6 +101,10 @@ func postJSON(t *testing.T, path string, body any) *http.Response {
 
 	req.Header.Set("Content-Type", "application/json")
 
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
 	resp, err := httpClient.Do(req)
 	if err != nil {
 		t.Fatalf("do request: %v", err)
@@ -397,3 +408,206 @@ func TestEndToEnd_ReassignOnMergedReturns409(t *testing.T) {
 		}
 	}
 }
+
+//nolint:bodyclose
+func TestEndToEnd_RepeatedMerge(t *testing.T) {
+	teamName := fmt.Sprintf("team-remerge-%d", time.Now().UnixNano())
+	authorID := "u13"
+	reviewerID := "u14"
+	prID := fmt.Sprintf("pr-remerge-%d", time.Now().UnixNano())
+
+	{
+		body := Team{
+			TeamName: teamName,
+			Members: []TeamMember{
+				{UserID: authorID, Username: "Author", IsActive: true},
+				{UserID: reviewerID, Username: "Reviewer", IsActive: true},
+			},
+		}
+
+		resp := postJSON(t, "/team/add", body)
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("expected 201 on /team/add, got %d", resp.StatusCode)
+		}
+
+		_ = resp.Body.Close()
+	}
+
+	{
+		body := map[string]any{
+			"pull_request_id":   prID,
+			"pull_request_name": "Test repeated merge",
+			"author_id":         authorID,
+		}
+
+		resp := postJSON(t, "/pullRequest/create", body)
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("expected 201 on /pullRequest/create, got %d", resp.StatusCode)
+		}
+
+		_ = resp.Body.Close()
+	}
+
+	merge := func(actorID string) PullRequest {
+		t.Helper()
+
+		body := map[string]any{
+			"pull_request_id": prID,
+		}
+
+		resp := postJSONWithHeaders(t, "/pullRequest/merge", body, map[string]string{"X-Actor-Id": actorID})
+		if resp.StatusCode != http.StatusOK {
+			var e ErrorResponse
+			decodeJSON(t, resp, &e)
+			t.Fatalf("expected 200 on /pullRequest/merge, got %d: %+v", resp.StatusCode, e)
+		}
+
+		var prResp PullRequestResponse
+		decodeJSON(t, resp, &prResp)
+
+		if prResp.PR.Status != "MERGED" {
+			t.Fatalf("expected status MERGED, got %s", prResp.PR.Status)
+		}
+
+		if prResp.PR.MergedAt == nil {
+			t.Fatalf("expected mergedAt to be set")
+		}
+
+		return prResp.PR
+	}
+
+	first := merge(authorID)
+
+	if first.MergedBy == nil || *first.MergedBy != authorID {
+		t.Fatalf("expected merged_by %s, got %v", authorID, first.MergedBy)
+	}
+
+	time.Sleep(1100 * time.Millisecond)
+
+	second := merge(reviewerID)
+
+	if *second.MergedAt != *first.MergedAt {
+		t.Fatalf("expected mergedAt to stay %s, got %s", *first.MergedAt, *second.MergedAt)
+	}
+
+	if second.MergedBy == nil || *second.MergedBy != authorID {
+		t.Fatalf("expected merged_by to stay %s, got %v", authorID, second.MergedBy)
+	}
+
+	{
+		q := url.Values{}
+		q.Set("pull_request_id", prID)
+
+		resp := get(t, "/pullRequest/get", q)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200 on /pullRequest/get, got %d", resp.StatusCode)
+		}
+
+		var prResp PullRequestResponse
+		decodeJSON(t, resp, &prResp)
+
+		if prResp.PR.MergedAt == nil || *prResp.PR.MergedAt != *first.MergedAt {
+			t.Fatalf("expected stored mergedAt %s, got %v", *first.MergedAt, prResp.PR.MergedAt)
+		}
+	}
+}
+
+//nolint:bodyclose
+func TestEndToEnd_DraftLifecycle(t *testing.T) {
+	teamName := fmt.Sprintf("team-draft-%d", time.Now().UnixNano())
+	authorID := "u20"
+	prID := fmt.Sprintf("pr-draft-%d", time.Now().UnixNano())
+
+	{
+		body := Team{
+			TeamName: teamName,
+			Members: []TeamMember{
+				{UserID: authorID, Username: "Author", IsActive: true},
+				{UserID: "u21", Username: "Reviewer", IsActive: true},
+			},
+		}
+
+		resp := postJSON(t, "/team/add", body)
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("expected 201 on /team/add, got %d", resp.StatusCode)
+		}
+
+		_ = resp.Body.Close()
+	}
+
+	{
+		body := map[string]any{
+			"pull_request_id":   prID,
+			"pull_request_name": "Draft",
+			"author_id":         authorID,
+			"draft":             true,
+		}
+
+		resp := postJSON(t, "/pullRequest/create", body)
+		if resp.StatusCode != http.StatusCreated {
+			t.Fatalf("expected 201 on /pullRequest/create, got %d", resp.StatusCode)
+		}
+
+		var prResp PullRequestResponse
+		decodeJSON(t, resp, &prResp)
+
+		if prResp.PR.Status != "DRAFT" {
+			t.Fatalf("expected status DRAFT, got: %s", prResp.PR.Status)
+		}
+
+		if len(prResp.PR.Assigned) != 0 {
+			t.Fatalf("draft must have no reviewers, got %v", prResp.PR.Assigned)
+		}
+	}
+
+	{
+		resp := postJSON(t, "/pullRequest/merge", map[string]any{"pull_request_id": prID})
+		if resp.StatusCode != http.StatusConflict {
+			t.Fatalf("expected 409 on merging a draft, got %d", resp.StatusCode)
+		}
+
+		var e ErrorResponse
+		decodeJSON(t, resp, &e)
+
+		if e.Error.Code != "PR_DRAFT" {
+			t.Fatalf("expected PR_DRAFT, got: %s", e.Error.Code)
+		}
+	}
+
+	{
+		resp := postJSON(t, "/pullRequest/ready", map[string]any{"pull_request_id": prID})
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200 on /pullRequest/ready, got %d", resp.StatusCode)
+		}
+
+		var prResp PullRequestResponse
+		decodeJSON(t, resp, &prResp)
+
+		if prResp.PR.Status != "OPEN" {
+			t.Fatalf("expected status OPEN, got: %s", prResp.PR.Status)
+		}
+	}
+
+	{
+		resp := postJSON(t, "/pullRequest/close", map[string]any{"pull_request_id": prID})
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200 on /pullRequest/close, got %d", resp.StatusCode)
+		}
+
+		_ = resp.Body.Close()
+	}
+
+	{
+		resp := postJSON(t, "/pullRequest/reopen", map[string]any{"pull_request_id": prID})
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected 200 on /pullRequest/reopen, got %d", resp.StatusCode)
+		}
+
+		var prResp PullRequestResponse
+		decodeJSON(t, resp, &prResp)
+
+		if prResp.PR.Status != "REOPENED" {
+			t.Fatalf("expected status REOPENED, got: %s", prResp.PR.Status)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package actor
+
+import (
+	"context"
+	"slices"
+)
+
+const (
+	RoleAdmin    = "admin"
+	RoleTeamLead = "team-lead"
+	RoleMember   = "member"
+)
+
+type Actor struct {
+	ID    string
+	Role  string
+	Teams []string
+}
+
+func (a *Actor) IsAdmin() bool {
+	return a.Role == RoleAdmin
+}
+
+func (a *Actor) HasRole(roles ...string) bool {
+	return slices.Contains(roles, a.Role)
+}
+
+func (a *Actor) CanManageTeam(teamName string) bool {
+	return a.IsAdmin() || a.Role == RoleTeamLead && slices.Contains(a.Teams, teamName)
+}
+
+func (a *Actor) CanManageAnyTeam(teamNames []string) bool {
+	return slices.ContainsFunc(teamNames, a.CanManageTeam)
+}
+
+type ctxKey struct{}
+
+func With(ctx context.Context, a *Actor) context.Context {
+	return context.WithValue(ctx, ctxKey{}, a)
+}
+
+func WithID(ctx context.Context, id string) context.Context {
+	return With(ctx, &Actor{ID: id})
+}
+
+func From(ctx context.Context) *Actor {
+	a, _ := ctx.Value(ctxKey{}).(*Actor)
+
+	return a
+}
+
+func FromContext(ctx context.Context) string {
+	if a := From(ctx); a != nil {
+		return a.ID
+	}
+
+	return ""
+}
@@ -0,0 +1,70 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+)
+
+type AuditService interface {
+	List(ctx context.Context, qp *model.AuditQueryParam) (*model.AuditListResponse, error)
+}
+
+type AuditHandler struct {
+	l   *zap.Logger
+	svc AuditService
+}
+
+func NewAuditHandler(l *zap.Logger, svc AuditService) *AuditHandler {
+	return &AuditHandler{
+		l:   l,
+		svc: svc,
+	}
+}
+
+func (h *AuditHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var qp model.AuditQueryParam
+	if err := c.ShouldBindQuery(&qp); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	events, err := h.svc.List(ctx, &qp)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, ResponseWithError{
+				Error: ResponseError{
+					Code:    "BAD_REQUEST",
+					Message: err.Error(),
+				},
+			})
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, events)
+}
@@ -0,0 +1,210 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/actor"
+	"avito-test-assignment/internal/api/http/middleware"
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+)
+
+type Authorizer interface {
+	CanManageUser(ctx context.Context, a *actor.Actor, userID string) (bool, error)
+	CanChangeMembersActivity(ctx context.Context, a *actor.Actor, members []model.UserRequest) (bool, error)
+	CanManageCodeOwnerRule(ctx context.Context, a *actor.Actor, ruleID int64) (bool, error)
+	CanManageUnavailability(ctx context.Context, a *actor.Actor, id int64) (bool, error)
+	CanManagePullRequest(ctx context.Context, a *actor.Actor, pullRequestID string) (bool, error)
+}
+
+type AuthService interface {
+	CreateToken(ctx context.Context, userID, name string) (*model.CreateAPITokenResponse, error)
+	ListTokens(ctx context.Context, userID string) (*model.APITokenListResponse, error)
+	RevokeToken(ctx context.Context, id int64) error
+	SetRole(ctx context.Context, userID, role string) (*model.UserRoleResponse, error)
+}
+
+type AuthHandler struct {
+	l   *zap.Logger
+	svc AuthService
+}
+
+func NewAuthHandler(l *zap.Logger, svc AuthService) *AuthHandler {
+	return &AuthHandler{
+		l:   l,
+		svc: svc,
+	}
+}
+
+func (h *AuthHandler) Me(c *gin.Context) {
+	a := middleware.CurrentActor(c)
+
+	teams := a.Teams
+	if teams == nil {
+		teams = []string{}
+	}
+
+	c.JSON(http.StatusOK, model.ActorResponse{
+		UserID: a.ID,
+		Role:   a.Role,
+		Teams:  teams,
+	})
+}
+
+func (h *AuthHandler) CreateToken(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.CreateAPITokenRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	response, err := h.svc.CreateToken(ctx, req.UserID, req.Name)
+	if err != nil {
+		h.handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *AuthHandler) ListTokens(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var qp model.APITokenQueryParam
+	if err := c.ShouldBindQuery(&qp); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	response, err := h.svc.ListTokens(ctx, qp.UserID)
+	if err != nil {
+		h.handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *AuthHandler) RevokeToken(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.APITokenIDRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	if err := h.svc.RevokeToken(ctx, req.ID); err != nil {
+		h.handleError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *AuthHandler) SetRole(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.SetUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	response, err := h.svc.SetRole(ctx, req.UserID, req.Role)
+	if err != nil {
+		h.handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *AuthHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrUserNotExist), errors.Is(err, apperrors.ErrAPITokenNotExist):
+		c.JSON(http.StatusNotFound, ResponseWithError{
+			Error: ResponseError{
+				Code:    "NOT_FOUND",
+				Message: "resource not found",
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+	}
+}
+
+func authorized(c *gin.Context, allowed bool, err error) bool {
+	switch {
+	case errors.Is(err, apperrors.ErrUserNotExist),
+		errors.Is(err, apperrors.ErrCodeOwnerRuleNotExist),
+		errors.Is(err, apperrors.ErrUnavailabilityNotExist),
+		errors.Is(err, apperrors.ErrPullRequestNotExist):
+		c.JSON(http.StatusNotFound, ResponseWithError{
+			Error: ResponseError{
+				Code:    "NOT_FOUND",
+				Message: "resource not found",
+			},
+		})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+	case !allowed:
+		forbidden(c)
+	}
+
+	return err == nil && allowed
+}
+
+func forbidden(c *gin.Context) {
+	c.JSON(http.StatusForbidden, ResponseWithError{
+		Error: ResponseError{
+			Code:    "FORBIDDEN",
+			Message: "action is not allowed for the actor",
+		},
+	})
+}
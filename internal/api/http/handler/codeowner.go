@@ -0,0 +1,173 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/api/http/middleware"
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+)
+
+type CodeOwnerService interface {
+	Add(ctx context.Context, req *model.AddCodeOwnerRuleRequest) (*model.CodeOwnerRule, error)
+	List(ctx context.Context, teamName string) (*model.CodeOwnerRulesResponse, error)
+	Delete(ctx context.Context, id int64) error
+	Match(ctx context.Context, teamName string, paths []string) (*model.CodeOwnerMatchResponse, error)
+}
+
+type CodeOwnerHandler struct {
+	l    *zap.Logger
+	svc  CodeOwnerService
+	auth Authorizer
+}
+
+func NewCodeOwnerHandler(l *zap.Logger, svc CodeOwnerService, auth Authorizer) *CodeOwnerHandler {
+	return &CodeOwnerHandler{
+		l:    l,
+		svc:  svc,
+		auth: auth,
+	}
+}
+
+func (h *CodeOwnerHandler) Add(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.AddCodeOwnerRuleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	if !middleware.CurrentActor(c).CanManageTeam(req.TeamName) {
+		forbidden(c)
+
+		return
+	}
+
+	rule, err := h.svc.Add(ctx, &req)
+	if err != nil {
+		h.handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, rule)
+}
+
+func (h *CodeOwnerHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var qp model.TeamNameQueryParam
+	if err := c.ShouldBindQuery(&qp); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	response, err := h.svc.List(ctx, qp.TeamName)
+	if err != nil {
+		h.handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *CodeOwnerHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.CodeOwnerRuleIDRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	allowed, err := h.auth.CanManageCodeOwnerRule(ctx, middleware.CurrentActor(c), req.ID)
+	if !authorized(c, allowed, err) {
+		return
+	}
+
+	if err := h.svc.Delete(ctx, req.ID); err != nil {
+		h.handleError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *CodeOwnerHandler) Match(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.CodeOwnerMatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	response, err := h.svc.Match(ctx, req.TeamName, req.Paths)
+	if err != nil {
+		h.handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *CodeOwnerHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrInvalidCodeOwnerPattern):
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	case errors.Is(err, apperrors.ErrTeamNotExist),
+		errors.Is(err, apperrors.ErrUserNotExist),
+		errors.Is(err, apperrors.ErrCodeOwnerRuleNotExist):
+		c.JSON(http.StatusNotFound, ResponseWithError{
+			Error: ResponseError{
+				Code:    "NOT_FOUND",
+				Message: "resource not found",
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+	}
+}
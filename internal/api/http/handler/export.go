@@ -0,0 +1,354 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/export"
+	"avito-test-assignment/internal/model"
+)
+
+type ExportService interface {
+	ExportPullRequests(ctx context.Context, qp *model.PullRequestExportQueryParam, fn func(*model.PullRequestExportRow) error) error
+	ExportAssignments(ctx context.Context, qp *model.AssignmentExportQueryParam, fn func(*model.AssignmentExportRow) error) error
+}
+
+type ExportHandler struct {
+	l     *zap.Logger
+	svc   ExportService
+	stats StatsService
+}
+
+func NewExportHandler(logger *zap.Logger, svc ExportService, stats StatsService) *ExportHandler {
+	return &ExportHandler{
+		l:     logger,
+		svc:   svc,
+		stats: stats,
+	}
+}
+
+var pullRequestExportColumns = []export.Column[*model.PullRequestExportRow]{
+	{Name: "pull_request_id", Value: func(pr *model.PullRequestExportRow) string { return pr.PullRequestID }},
+	{Name: "pull_request_name", Value: func(pr *model.PullRequestExportRow) string { return pr.PullRequestName }},
+	{Name: "author_id", Value: func(pr *model.PullRequestExportRow) string { return pr.AuthorID }},
+	{Name: "status", Value: func(pr *model.PullRequestExportRow) string { return pr.Status }},
+	{Name: "team_name", Value: func(pr *model.PullRequestExportRow) string { return pr.TeamName }},
+	{Name: "assigned_reviewers", Value: func(pr *model.PullRequestExportRow) string { return export.List(pr.Reviewers) }},
+	{Name: "created_at", Value: func(pr *model.PullRequestExportRow) string { return export.Time(pr.CreatedAt) }},
+	{Name: "merged_at", Value: func(pr *model.PullRequestExportRow) string { return export.Time(pr.MergedAt) }},
+	{Name: "merged_by", Value: func(pr *model.PullRequestExportRow) string {
+		if pr.MergedBy == nil {
+			return ""
+		}
+
+		return *pr.MergedBy
+	}},
+}
+
+var assignmentExportColumns = []export.Column[*model.AssignmentExportRow]{
+	{Name: "id", Value: func(e *model.AssignmentExportRow) string { return strconv.FormatInt(e.ID, 10) }},
+	{Name: "created_at", Value: func(e *model.AssignmentExportRow) string { return export.Time(&e.CreatedAt) }},
+	{Name: "actor", Value: func(e *model.AssignmentExportRow) string { return e.Actor }},
+	{Name: "action", Value: func(e *model.AssignmentExportRow) string { return e.Action }},
+	{Name: "pull_request_id", Value: func(e *model.AssignmentExportRow) string { return e.PullRequestID }},
+	{Name: "user_id", Value: func(e *model.AssignmentExportRow) string { return e.UserID }},
+	{Name: "team_name", Value: func(e *model.AssignmentExportRow) string { return e.TeamName }},
+	{Name: "reason", Value: func(e *model.AssignmentExportRow) string { return e.Reason }},
+	{Name: "replaced_by", Value: func(e *model.AssignmentExportRow) string { return export.List(e.ReplacedBy) }},
+	{Name: "reviewers_before", Value: func(e *model.AssignmentExportRow) string { return export.List(e.ReviewersBefore) }},
+	{Name: "reviewers_after", Value: func(e *model.AssignmentExportRow) string { return export.List(e.ReviewersAfter) }},
+}
+
+var reviewerStatsExportColumns = []export.Column[model.ReviewerStats]{
+	{Name: "reviewer_id", Value: func(s model.ReviewerStats) string { return s.ReviewerID }},
+	{Name: "assigned_count", Value: func(s model.ReviewerStats) string { return strconv.Itoa(s.AssignedCount) }},
+}
+
+var prStatsExportColumns = []export.Column[model.PRStats]{
+	{Name: "pull_request_id", Value: func(s model.PRStats) string { return s.PullRequestID }},
+	{Name: "reviewer_count", Value: func(s model.PRStats) string { return strconv.Itoa(s.ReviewerCount) }},
+}
+
+var escalationStatsExportColumns = []export.Column[model.EscalationStats]{
+	{Name: "team_name", Value: func(s model.EscalationStats) string { return s.TeamName }},
+	{Name: "reassigned", Value: func(s model.EscalationStats) string { return strconv.Itoa(s.Reassigned) }},
+	{Name: "reviewers_added", Value: func(s model.EscalationStats) string { return strconv.Itoa(s.ReviewersAdded) }},
+	{Name: "no_candidate", Value: func(s model.EscalationStats) string { return strconv.Itoa(s.NoCandidate) }},
+	{Name: "last_escalated_at", Value: func(s model.EscalationStats) string { return export.Time(s.LastEscalatedAt) }},
+}
+
+var mergeTimeExportColumns = []export.Column[model.MergeTimeStats]{
+	{Name: "team_name", Value: func(s model.MergeTimeStats) string { return s.TeamName }},
+	{Name: "author_id", Value: func(s model.MergeTimeStats) string { return s.AuthorID }},
+	{Name: "merged", Value: func(s model.MergeTimeStats) string { return strconv.Itoa(s.Merged) }},
+	{Name: "median_seconds", Value: func(s model.MergeTimeStats) string { return formatFloat(s.MedianSeconds) }},
+	{Name: "p90_seconds", Value: func(s model.MergeTimeStats) string { return formatFloat(s.P90Seconds) }},
+}
+
+var openLoadExportColumns = []export.Column[model.OpenLoadStats]{
+	{Name: "reviewer_id", Value: func(s model.OpenLoadStats) string { return s.ReviewerID }},
+	{Name: "open_reviews", Value: func(s model.OpenLoadStats) string { return strconv.Itoa(s.OpenReviews) }},
+}
+
+var reassignmentExportColumns = []export.Column[model.ReassignmentStats]{
+	{Name: "team_name", Value: func(s model.ReassignmentStats) string { return s.TeamName }},
+	{Name: "reason", Value: func(s model.ReassignmentStats) string { return s.Reason }},
+	{Name: "count", Value: func(s model.ReassignmentStats) string { return strconv.Itoa(s.Count) }},
+}
+
+var mergedPerWeekExportColumns = []export.Column[model.MergedPerWeekStats]{
+	{Name: "week_start", Value: func(s model.MergedPerWeekStats) string { return export.Time(&s.WeekStart) }},
+	{Name: "merged", Value: func(s model.MergedPerWeekStats) string { return strconv.Itoa(s.Merged) }},
+}
+
+var fairnessExportColumns = []export.Column[model.FairnessExportRow]{
+	{Name: "team_name", Value: func(r model.FairnessExportRow) string { return r.TeamName }},
+	{Name: "gini_index", Value: func(r model.FairnessExportRow) string { return formatFloat(r.GiniIndex) }},
+	{Name: "user_id", Value: func(r model.FairnessExportRow) string { return r.UserID }},
+	{Name: "username", Value: func(r model.FairnessExportRow) string { return r.Username }},
+	{Name: "is_active", Value: func(r model.FairnessExportRow) string { return strconv.FormatBool(r.IsActive) }},
+	{Name: "active_days", Value: func(r model.FairnessExportRow) string { return formatFloat(r.ActiveDays) }},
+	{Name: "assignments", Value: func(r model.FairnessExportRow) string { return strconv.Itoa(r.Assignments) }},
+	{Name: "share", Value: func(r model.FairnessExportRow) string { return formatFloat(r.Share) }},
+	{Name: "expected_share", Value: func(r model.FairnessExportRow) string { return formatFloat(r.ExpectedShare) }},
+	{Name: "status", Value: func(r model.FairnessExportRow) string { return r.Status }},
+}
+
+func (h *ExportHandler) PullRequests(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var qp model.PullRequestExportQueryParam
+	if err := c.ShouldBindQuery(&qp); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	format := export.Negotiate(qp.Format, c.GetHeader("Accept"))
+
+	writeExport(h, c, "pull_requests", format, pullRequestExportColumns, func(fn func(*model.PullRequestExportRow) error) error {
+		return h.svc.ExportPullRequests(ctx, &qp, fn)
+	})
+}
+
+func (h *ExportHandler) Assignments(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var qp model.AssignmentExportQueryParam
+	if err := c.ShouldBindQuery(&qp); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	format := export.Negotiate(qp.Format, c.GetHeader("Accept"))
+
+	writeExport(h, c, "assignments", format, assignmentExportColumns, func(fn func(*model.AssignmentExportRow) error) error {
+		return h.svc.ExportAssignments(ctx, &qp, fn)
+	})
+}
+
+func (h *ExportHandler) Stats(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var qp model.StatsExportQueryParam
+	if err := c.ShouldBindQuery(&qp); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	format := export.Negotiate(qp.Format, c.GetHeader("Accept"))
+	filter := model.StatsFilter{TeamName: qp.TeamName, From: qp.From, To: qp.To}
+	name := "stats_" + qp.Report
+
+	switch qp.Report {
+	case model.ExportReportReviewers:
+		writeExport(h, c, name, format, reviewerStatsExportColumns, func(fn func(model.ReviewerStats) error) error {
+			stats, err := h.stats.GetStats(ctx)
+			if err != nil {
+				return err
+			}
+
+			return encodeAll(stats.ReviewerStats, fn)
+		})
+	case model.ExportReportPullRequests:
+		writeExport(h, c, name, format, prStatsExportColumns, func(fn func(model.PRStats) error) error {
+			stats, err := h.stats.GetStats(ctx)
+			if err != nil {
+				return err
+			}
+
+			return encodeAll(stats.PRStats, fn)
+		})
+	case model.ExportReportEscalations:
+		writeExport(h, c, name, format, escalationStatsExportColumns, func(fn func(model.EscalationStats) error) error {
+			stats, err := h.stats.GetStats(ctx)
+			if err != nil {
+				return err
+			}
+
+			return encodeAll(stats.Escalations, fn)
+		})
+	case model.ExportReportMergeTimeTeam, model.ExportReportMergeTimeAuthor:
+		writeExport(h, c, name, format, mergeTimeExportColumns, func(fn func(model.MergeTimeStats) error) error {
+			analytics, err := h.stats.GetAnalytics(ctx, filter)
+			if err != nil {
+				return err
+			}
+
+			if qp.Report == model.ExportReportMergeTimeAuthor {
+				return encodeAll(analytics.MergeTimeByAuthor, fn)
+			}
+
+			return encodeAll(analytics.MergeTimeByTeam, fn)
+		})
+	case model.ExportReportOpenLoad:
+		writeExport(h, c, name, format, openLoadExportColumns, func(fn func(model.OpenLoadStats) error) error {
+			analytics, err := h.stats.GetAnalytics(ctx, filter)
+			if err != nil {
+				return err
+			}
+
+			return encodeAll(analytics.OpenLoad, fn)
+		})
+	case model.ExportReportReassignments:
+		writeExport(h, c, name, format, reassignmentExportColumns, func(fn func(model.ReassignmentStats) error) error {
+			analytics, err := h.stats.GetAnalytics(ctx, filter)
+			if err != nil {
+				return err
+			}
+
+			return encodeAll(analytics.Reassignments, fn)
+		})
+	case model.ExportReportMergedPerWeek:
+		writeExport(h, c, name, format, mergedPerWeekExportColumns, func(fn func(model.MergedPerWeekStats) error) error {
+			analytics, err := h.stats.GetAnalytics(ctx, filter)
+			if err != nil {
+				return err
+			}
+
+			return encodeAll(analytics.MergedPerWeek, fn)
+		})
+	case model.ExportReportFairness:
+		writeExport(h, c, name, format, fairnessExportColumns, func(fn func(model.FairnessExportRow) error) error {
+			fairness, err := h.stats.GetFairness(ctx, filter)
+			if err != nil {
+				return err
+			}
+
+			for _, team := range fairness.Teams {
+				for _, member := range team.Members {
+					err := fn(model.FairnessExportRow{TeamName: team.TeamName, GiniIndex: team.GiniIndex, MemberFairness: member})
+					if err != nil {
+						return err
+					}
+				}
+			}
+
+			return nil
+		})
+	default:
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: "unknown report " + strconv.Quote(qp.Report),
+			},
+		})
+	}
+}
+
+// writeExport reports errors as JSON only until the first record has been sent.
+func writeExport[T any](
+	h *ExportHandler,
+	c *gin.Context,
+	name, format string,
+	columns []export.Column[T],
+	stream func(fn func(T) error) error,
+) {
+	c.Header("Content-Type", export.ContentType(format))
+	c.Header("Content-Disposition", `attachment; filename="`+export.FileName(name, format)+`"`)
+
+	// An export may write past the server write timeout, until its own request deadline.
+	if deadline, ok := c.Request.Context().Deadline(); ok {
+		if err := http.NewResponseController(c.Writer).SetWriteDeadline(deadline); err != nil {
+			h.l.Warn("Failed to extend export write deadline", zap.String("export", name), zap.Error(err))
+		}
+	}
+
+	enc := export.NewEncoder(c.Writer, format, columns)
+
+	err := stream(enc.Encode)
+	if err == nil {
+		err = enc.Close()
+	}
+
+	if err == nil {
+		return
+	}
+
+	if c.Writer.Written() {
+		h.l.Error("Failed to stream export", zap.String("export", name), zap.Error(err))
+		c.Abort()
+
+		return
+	}
+
+	c.Header("Content-Type", "")
+	c.Header("Content-Disposition", "")
+	h.handleError(c, err)
+}
+
+func (h *ExportHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrInvalidTimeRange):
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+	}
+}
+
+func encodeAll[T any](records []T, fn func(T) error) error {
+	for _, r := range records {
+		if err := fn(r); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
@@ -27,7 +27,9 @@ type ResponseWithMessage struct {
 }
 
 type ResponseWithUser struct {
-	User *model.UserResponseWithTeamName `json:"user"`
+	User       *model.UserResponseWithTeamName `json:"user"`
+	Reassigned []model.ReviewReassignment      `json:"reassigned,omitempty"`
+	Uncovered  []model.ReviewReassignment      `json:"uncovered,omitempty"`
 }
 
 type ResponseWithPR struct {
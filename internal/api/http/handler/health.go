@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/model"
+)
+
+type HealthService interface {
+	Ready(ctx context.Context) (*model.ReadinessResponse, bool)
+}
+
+type HealthHandler struct {
+	l   *zap.Logger
+	svc HealthService
+}
+
+func NewHealthHandler(logger *zap.Logger, svc HealthService) *HealthHandler {
+	return &HealthHandler{
+		l:   logger,
+		svc: svc,
+	}
+}
+
+func (h *HealthHandler) Healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, model.HealthResponse{Status: model.HealthStatusOK})
+}
+
+func (h *HealthHandler) Readyz(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	response, ready := h.svc.Ready(ctx)
+	if !ready {
+		h.l.Warn("Service is not ready", zap.String("status", response.Status))
+
+		c.JSON(http.StatusServiceUnavailable, response)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -8,23 +8,37 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"avito-test-assignment/internal/api/http/middleware"
 	"avito-test-assignment/internal/apperrors"
 	"avito-test-assignment/internal/model"
 )
 
 type PullRequestService interface {
-	Create(ctx context.Context, id, name, authorID string) (*model.PullRequestWithAssignedReviewers, error)
+	Create(
+		ctx context.Context,
+		id, name, authorID string,
+		teamID *int,
+		draft bool,
+		changedPaths []string,
+	) (*model.PullRequestWithAssignedReviewers, error)
 	Merge(ctx context.Context, pullRequestID string) (*model.MergedResponse, error)
 	Reassign(ctx context.Context, pullRequestID, oldReviewerID string) (*model.ReassignResponse, error)
+	Ready(ctx context.Context, pullRequestID string) (*model.PullRequestWithAssignedReviewers, error)
+	Close(ctx context.Context, pullRequestID string) (*model.PullRequestWithAssignedReviewers, error)
+	Reopen(ctx context.Context, pullRequestID string) (*model.PullRequestWithAssignedReviewers, error)
+	Review(ctx context.Context, pullRequestID, reviewerID, verdict, comment string) (*model.PullRequestWithAssignedReviewers, error)
+	Get(ctx context.Context, pullRequestID string) (*model.PullRequest, error)
+	List(ctx context.Context, qp *model.PullRequestListQueryParam) (*model.PullRequestListResponse, error)
 }
 
 type PullRequestHandler struct {
-	l   *zap.Logger
-	svc PullRequestService
+	l    *zap.Logger
+	svc  PullRequestService
+	auth Authorizer
 }
 
-func NewPullRequestHandler(logger *zap.Logger, svc PullRequestService) *PullRequestHandler {
-	return &PullRequestHandler{l: logger, svc: svc}
+func NewPullRequestHandler(logger *zap.Logger, svc PullRequestService, auth Authorizer) *PullRequestHandler {
+	return &PullRequestHandler{l: logger, svc: svc, auth: auth}
 }
 
 func (s *PullRequestHandler) Create(c *gin.Context) {
@@ -38,9 +52,17 @@ func (s *PullRequestHandler) Create(c *gin.Context) {
 				Message: err.Error(),
 			},
 		})
+
+		return
+	}
+
+	if a := middleware.CurrentActor(c); !a.IsAdmin() && a.ID != req.AuthorID {
+		forbidden(c)
+
+		return
 	}
 
-	pr, err := s.svc.Create(ctx, req.PullRequestID, req.PullRequestName, req.AuthorID)
+	pr, err := s.svc.Create(ctx, req.PullRequestID, req.PullRequestName, req.AuthorID, req.TeamID, req.Draft, req.ChangedPaths)
 	if err != nil {
 		if errors.Is(err, apperrors.ErrUserNotExist) || errors.Is(err, apperrors.ErrTeamNotExist) {
 			c.JSON(http.StatusNotFound, ResponseWithError{
@@ -64,6 +86,28 @@ func (s *PullRequestHandler) Create(c *gin.Context) {
 			return
 		}
 
+		if errors.Is(err, apperrors.ErrTeamAmbiguous) {
+			c.JSON(http.StatusConflict, ResponseWithError{
+				Error: ResponseError{
+					Code:    "TEAM_AMBIGUOUS",
+					Message: "author belongs to several teams, team_id is required",
+				},
+			})
+
+			return
+		}
+
+		if errors.Is(err, apperrors.ErrUserNotInTeam) {
+			c.JSON(http.StatusConflict, ResponseWithError{
+				Error: ResponseError{
+					Code:    "NOT_TEAM_MEMBER",
+					Message: "author is not a member of the team",
+				},
+			})
+
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, ResponseWithError{
 			Error: ResponseError{
 				Code:    "INTERNAL_ERROR",
@@ -90,6 +134,13 @@ func (s *PullRequestHandler) Merge(c *gin.Context) {
 				Message: err.Error(),
 			},
 		})
+
+		return
+	}
+
+	allowed, err := s.auth.CanManagePullRequest(ctx, middleware.CurrentActor(c), req.PullRequestID)
+	if !authorized(c, allowed, err) {
+		return
 	}
 
 	pr, err := s.svc.Merge(ctx, req.PullRequestID)
@@ -105,6 +156,12 @@ func (s *PullRequestHandler) Merge(c *gin.Context) {
 			return
 		}
 
+		if resp, ok := statusConflict(err); ok {
+			c.JSON(http.StatusConflict, resp)
+
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, ResponseWithError{
 			Error: ResponseError{
 				Code:    "INTERNAL_ERROR",
@@ -131,6 +188,15 @@ func (s *PullRequestHandler) Reassign(c *gin.Context) {
 				Message: err.Error(),
 			},
 		})
+
+		return
+	}
+
+	if a := middleware.CurrentActor(c); a.ID != req.OldReviewerID {
+		allowed, err := s.auth.CanManageUser(ctx, a, req.OldReviewerID)
+		if !authorized(c, allowed, err) {
+			return
+		}
 	}
 
 	pr, err := s.svc.Reassign(ctx, req.PullRequestID, req.OldReviewerID)
@@ -157,6 +223,12 @@ func (s *PullRequestHandler) Reassign(c *gin.Context) {
 			return
 		}
 
+		if resp, ok := statusConflict(err); ok {
+			c.JSON(http.StatusConflict, resp)
+
+			return
+		}
+
 		if errors.Is(err, apperrors.ErrUserIsNotAssignedAsReviewer) {
 			c.JSON(http.StatusConflict, ResponseWithError{
 				Error: ResponseError{
@@ -172,7 +244,7 @@ func (s *PullRequestHandler) Reassign(c *gin.Context) {
 			c.JSON(http.StatusConflict, ResponseWithError{
 				Error: ResponseError{
 					Code:    "NO_CANDIDATE",
-					Message: "no active replacement candidate in team",
+					Message: "no available replacement candidate in team or its reviewer pools",
 				},
 			})
 
@@ -191,3 +263,251 @@ func (s *PullRequestHandler) Reassign(c *gin.Context) {
 
 	c.JSON(http.StatusOK, pr)
 }
+
+func (s *PullRequestHandler) Ready(c *gin.Context) {
+	s.changeStatus(c, PullRequestService.Ready)
+}
+
+func (s *PullRequestHandler) Close(c *gin.Context) {
+	s.changeStatus(c, PullRequestService.Close)
+}
+
+func (s *PullRequestHandler) Reopen(c *gin.Context) {
+	s.changeStatus(c, PullRequestService.Reopen)
+}
+
+func (s *PullRequestHandler) Review(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.ReviewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	if a := middleware.CurrentActor(c); a.ID != req.ReviewerID {
+		allowed, err := s.auth.CanManageUser(ctx, a, req.ReviewerID)
+		if !authorized(c, allowed, err) {
+			return
+		}
+	}
+
+	pr, err := s.svc.Review(ctx, req.PullRequestID, req.ReviewerID, req.Verdict, req.Comment)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrPullRequestNotExist) || errors.Is(err, apperrors.ErrUserNotExist) {
+			c.JSON(http.StatusNotFound, ResponseWithError{
+				Error: ResponseError{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+
+			return
+		}
+
+		if errors.Is(err, apperrors.ErrUserIsNotAssignedAsReviewer) {
+			c.JSON(http.StatusConflict, ResponseWithError{
+				Error: ResponseError{
+					Code:    "NOT_ASSIGNED",
+					Message: "reviewer is not assigned to this PR",
+				},
+			})
+
+			return
+		}
+
+		if resp, ok := statusConflict(err); ok {
+			c.JSON(http.StatusConflict, resp)
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, ResponseWithPR{
+		PR: pr,
+	})
+}
+
+func (s *PullRequestHandler) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var qp model.PullRequestIDQueryParam
+	if err := c.ShouldBindQuery(&qp); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	pr, err := s.svc.Get(ctx, qp.PullRequestID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrPullRequestNotExist) {
+			c.JSON(http.StatusNotFound, ResponseWithError{
+				Error: ResponseError{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ResponseWithPR{
+		PR: pr,
+	})
+}
+
+func (s *PullRequestHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var qp model.PullRequestListQueryParam
+	if err := c.ShouldBindQuery(&qp); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	prs, err := s.svc.List(ctx, &qp)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrInvalidCursor) {
+			c.JSON(http.StatusBadRequest, ResponseWithError{
+				Error: ResponseError{
+					Code:    "BAD_REQUEST",
+					Message: err.Error(),
+				},
+			})
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, prs)
+}
+
+func (s *PullRequestHandler) changeStatus(
+	c *gin.Context,
+	change func(svc PullRequestService, ctx context.Context, pullRequestID string) (*model.PullRequestWithAssignedReviewers, error),
+) {
+	ctx := c.Request.Context()
+
+	var req model.PullRequestStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	allowed, err := s.auth.CanManagePullRequest(ctx, middleware.CurrentActor(c), req.PullRequestID)
+	if !authorized(c, allowed, err) {
+		return
+	}
+
+	pr, err := change(s.svc, ctx, req.PullRequestID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrPullRequestNotExist) || errors.Is(err, apperrors.ErrTeamNotExist) {
+			c.JSON(http.StatusNotFound, ResponseWithError{
+				Error: ResponseError{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+
+			return
+		}
+
+		if resp, ok := statusConflict(err); ok {
+			c.JSON(http.StatusConflict, resp)
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, ResponseWithPR{
+		PR: pr,
+	})
+}
+
+func statusConflict(err error) (ResponseWithError, bool) {
+	var code string
+
+	switch {
+	case errors.Is(err, apperrors.ErrPullRequestAlreadyMerged):
+		code = "PR_MERGED"
+	case errors.Is(err, apperrors.ErrPullRequestClosed):
+		code = "PR_CLOSED"
+	case errors.Is(err, apperrors.ErrPullRequestIsDraft):
+		code = "PR_DRAFT"
+	case errors.Is(err, apperrors.ErrInvalidStatusTransition):
+		code = "INVALID_TRANSITION"
+	case errors.Is(err, apperrors.ErrNotEnoughApprovals):
+		code = "NOT_APPROVED"
+	case errors.Is(err, apperrors.ErrPullRequestStatusChanged):
+		code = "STATUS_CHANGED"
+	default:
+		return ResponseWithError{}, false
+	}
+
+	return ResponseWithError{
+		Error: ResponseError{
+			Code:    code,
+			Message: err.Error(),
+		},
+	}, true
+}
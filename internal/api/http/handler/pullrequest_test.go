@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/actor"
+	"avito-test-assignment/internal/api/http/middleware"
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+)
+
+type fakeAuthenticator map[string]*actor.Actor
+
+func (f fakeAuthenticator) Authenticate(_ context.Context, token string) (*actor.Actor, error) {
+	a, ok := f[token]
+	if !ok {
+		return nil, apperrors.ErrUnauthorized
+	}
+
+	return a, nil
+}
+
+type fakeAuthorizer struct {
+	Authorizer
+
+	userTeams map[string][]string
+}
+
+func (f *fakeAuthorizer) CanManageUser(_ context.Context, a *actor.Actor, userID string) (bool, error) {
+	return a.CanManageAnyTeam(f.userTeams[userID]), nil
+}
+
+type fakePullRequestService struct {
+	PullRequestService
+
+	reviewed, reassigned bool
+}
+
+func (f *fakePullRequestService) Review(_ context.Context, id, _, _, _ string) (*model.PullRequestWithAssignedReviewers, error) {
+	f.reviewed = true
+
+	return &model.PullRequestWithAssignedReviewers{PullRequestID: id}, nil
+}
+
+func (f *fakePullRequestService) Reassign(_ context.Context, id, _ string) (*model.ReassignResponse, error) {
+	f.reassigned = true
+
+	return &model.ReassignResponse{PR: model.PullRequestWithAssignedReviewers{PullRequestID: id}}, nil
+}
+
+var testActors = fakeAuthenticator{
+	"admin":    {ID: "admin", Role: actor.RoleAdmin},
+	"lead":     {ID: "lead", Role: actor.RoleTeamLead, Teams: []string{"backend"}},
+	"u1-token": {ID: "u1", Role: actor.RoleMember, Teams: []string{"backend"}},
+}
+
+func newPullRequestRouter(svc PullRequestService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	h := NewPullRequestHandler(zap.NewNop(), svc, &fakeAuthorizer{userTeams: map[string][]string{
+		"u1": {"backend"},
+		"u2": {"backend"},
+		"u3": {"frontend"},
+	}})
+
+	router := gin.New()
+	router.Use(middleware.Auth(zap.NewNop(), testActors))
+	router.POST("/pullRequest/review", h.Review)
+	router.POST("/pullRequest/reassign", h.Reassign)
+
+	return router
+}
+
+func doJSON(router http.Handler, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestReviewRequiresReviewer(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		reviewer string
+		want     int
+	}{
+		{"own review", "u1-token", "u1", http.StatusCreated},
+		{"member for another reviewer", "u1-token", "u2", http.StatusForbidden},
+		{"lead for own team member", "lead", "u2", http.StatusCreated},
+		{"lead for another team member", "lead", "u3", http.StatusForbidden},
+		{"admin", "admin", "u3", http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &fakePullRequestService{}
+			router := newPullRequestRouter(svc)
+
+			rec := doJSON(router, "/pullRequest/review", tt.token,
+				`{"pull_request_id":"pr-1","reviewer_id":"`+tt.reviewer+`","verdict":"APPROVED"}`)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+
+			if svc.reviewed != (tt.want == http.StatusCreated) {
+				t.Fatalf("service called = %v for status %d", svc.reviewed, rec.Code)
+			}
+		})
+	}
+}
+
+func TestReassignRequiresManager(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+		old   string
+		want  int
+	}{
+		{"member reassigning self", "u1-token", "u1", http.StatusOK},
+		{"member reassigning someone else", "u1-token", "u2", http.StatusForbidden},
+		{"lead reassigning own team member", "lead", "u2", http.StatusOK},
+		{"lead reassigning another team member", "lead", "u3", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &fakePullRequestService{}
+			router := newPullRequestRouter(svc)
+
+			rec := doJSON(router, "/pullRequest/reassign", tt.token, `{"pull_request_id":"pr-1","old_reviewer_id":"`+tt.old+`"}`)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+
+			if svc.reassigned != (tt.want == http.StatusOK) {
+				t.Fatalf("service called = %v for status %d", svc.reassigned, rec.Code)
+			}
+		})
+	}
+}
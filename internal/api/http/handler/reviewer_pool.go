@@ -0,0 +1,252 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+)
+
+type ReviewerPoolService interface {
+	Create(ctx context.Context, poolName string, members []string) (*model.ReviewerPool, error)
+	Get(ctx context.Context, poolName string) (*model.ReviewerPool, error)
+	List(ctx context.Context) (*model.ReviewerPoolListResponse, error)
+	Delete(ctx context.Context, poolName string) error
+	AddMembers(ctx context.Context, poolName string, userIDs []string) (*model.ReviewerPool, error)
+	RemoveMembers(ctx context.Context, poolName string, userIDs []string) (*model.ReviewerPool, error)
+	Attach(ctx context.Context, teamName, poolName, mode string) (*model.ReviewerPool, error)
+	Detach(ctx context.Context, teamName, poolName string) (*model.ReviewerPool, error)
+}
+
+type ReviewerPoolHandler struct {
+	l   *zap.Logger
+	svc ReviewerPoolService
+}
+
+func NewReviewerPoolHandler(l *zap.Logger, svc ReviewerPoolService) *ReviewerPoolHandler {
+	return &ReviewerPoolHandler{
+		l:   l,
+		svc: svc,
+	}
+}
+
+func (h *ReviewerPoolHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.CreateReviewerPoolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	pool, err := h.svc.Create(ctx, req.PoolName, req.Members)
+	if err != nil {
+		h.handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, pool)
+}
+
+func (h *ReviewerPoolHandler) Get(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var qp model.ReviewerPoolNameQueryParam
+	if err := c.ShouldBindQuery(&qp); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	pool, err := h.svc.Get(ctx, qp.PoolName)
+	if err != nil {
+		h.handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, pool)
+}
+
+func (h *ReviewerPoolHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	response, err := h.svc.List(ctx)
+	if err != nil {
+		h.handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *ReviewerPoolHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.ReviewerPoolNameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	if err := h.svc.Delete(ctx, req.PoolName); err != nil {
+		h.handleError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *ReviewerPoolHandler) AddMembers(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.ReviewerPoolMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	pool, err := h.svc.AddMembers(ctx, req.PoolName, req.UserIDs)
+	if err != nil {
+		h.handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, pool)
+}
+
+func (h *ReviewerPoolHandler) RemoveMembers(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.ReviewerPoolMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	pool, err := h.svc.RemoveMembers(ctx, req.PoolName, req.UserIDs)
+	if err != nil {
+		h.handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, pool)
+}
+
+func (h *ReviewerPoolHandler) Attach(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.AttachReviewerPoolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	pool, err := h.svc.Attach(ctx, req.TeamName, req.PoolName, req.Mode)
+	if err != nil {
+		h.handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, pool)
+}
+
+func (h *ReviewerPoolHandler) Detach(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.DetachReviewerPoolRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	pool, err := h.svc.Detach(ctx, req.TeamName, req.PoolName)
+	if err != nil {
+		h.handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, pool)
+}
+
+func (h *ReviewerPoolHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrReviewerPoolAlreadyExists):
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "POOL_EXISTS",
+				Message: err.Error(),
+			},
+		})
+	case errors.Is(err, apperrors.ErrReviewerPoolNotExist),
+		errors.Is(err, apperrors.ErrReviewerPoolNotAttached),
+		errors.Is(err, apperrors.ErrTeamNotExist),
+		errors.Is(err, apperrors.ErrUserNotExist):
+		c.JSON(http.StatusNotFound, ResponseWithError{
+			Error: ResponseError{
+				Code:    "NOT_FOUND",
+				Message: "resource not found",
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+	}
+}
@@ -2,16 +2,20 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"avito-test-assignment/internal/apperrors"
 	"avito-test-assignment/internal/model"
 )
 
 type StatsService interface {
 	GetStats(ctx context.Context) (response *model.StatsResponse, err error)
+	GetAnalytics(ctx context.Context, filter model.StatsFilter) (*model.AnalyticsResponse, error)
+	GetFairness(ctx context.Context, filter model.StatsFilter) (*model.FairnessResponse, error)
 }
 
 type StatsHandler struct {
@@ -37,7 +41,88 @@ func (h *StatsHandler) GetStats(c *gin.Context) {
 				Message: err.Error(),
 			},
 		})
+
+		return
 	}
 
 	c.JSON(http.StatusOK, stats)
 }
+
+func (h *StatsHandler) GetAnalytics(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var query model.StatsQueryParam
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	analytics, err := h.svc.GetAnalytics(ctx, model.StatsFilter{
+		TeamName: query.TeamName,
+		From:     query.From,
+		To:       query.To,
+	})
+	if err != nil {
+		h.handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, analytics)
+}
+
+func (h *StatsHandler) GetFairness(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var query model.StatsQueryParam
+
+	if err := c.ShouldBindQuery(&query); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	fairness, err := h.svc.GetFairness(ctx, model.StatsFilter{
+		TeamName: query.TeamName,
+		From:     query.From,
+		To:       query.To,
+	})
+	if err != nil {
+		h.handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, fairness)
+}
+
+func (h *StatsHandler) handleError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrInvalidTimeRange):
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+	}
+}
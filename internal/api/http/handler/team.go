@@ -8,6 +8,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"avito-test-assignment/internal/api/http/middleware"
 	"avito-test-assignment/internal/apperrors"
 	"avito-test-assignment/internal/model"
 )
@@ -15,15 +16,23 @@ import (
 type TeamService interface {
 	AddTeam(ctx context.Context, teamName string, members []model.UserRequest) (err error)
 	GetTeam(ctx context.Context, teamName string) (team *model.TeamResponse, err error)
+	SetReviewersCount(ctx context.Context, teamName string, count int) (team *model.TeamResponse, err error)
+	SetRequiredApprovals(ctx context.Context, teamName string, count int) (team *model.TeamResponse, err error)
+	SetMaxOpenReviews(ctx context.Context, teamName string, limit *int) (team *model.TeamResponse, err error)
+	SetReviewSLA(ctx context.Context, teamName string, minutes *int, action string) (team *model.TeamResponse, err error)
+	AddMembers(ctx context.Context, teamName string, members []model.UserRequest) (response *model.TeamMembersResponse, err error)
+	RemoveMembers(ctx context.Context, teamName string, userIDs []string) (response *model.TeamMembersResponse, err error)
+	MoveMember(ctx context.Context, userID, fromTeam, toTeam string) (response *model.MoveTeamMemberResponse, err error)
 }
 
 type TeamHandler struct {
-	l   *zap.Logger
-	svc TeamService
+	l    *zap.Logger
+	svc  TeamService
+	auth Authorizer
 }
 
-func NewTeamHandler(l *zap.Logger, svc TeamService) *TeamHandler {
-	return &TeamHandler{l, svc}
+func NewTeamHandler(l *zap.Logger, svc TeamService, auth Authorizer) *TeamHandler {
+	return &TeamHandler{l, svc, auth}
 }
 
 func (h *TeamHandler) AddTeam(c *gin.Context) {
@@ -102,3 +111,338 @@ func (h *TeamHandler) GetTeam(c *gin.Context) {
 
 	c.JSON(http.StatusOK, team)
 }
+
+func (h *TeamHandler) SetReviewersCount(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.SetReviewersCountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	if !middleware.CurrentActor(c).CanManageTeam(req.TeamName) {
+		forbidden(c)
+
+		return
+	}
+
+	team, err := h.svc.SetReviewersCount(ctx, req.TeamName, req.ReviewersCount)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrTeamNotExist) {
+			c.JSON(http.StatusNotFound, ResponseWithError{
+				Error: ResponseError{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+
+			return
+		}
+
+		if errors.Is(err, apperrors.ErrApprovalsExceedReviewers) {
+			c.JSON(http.StatusBadRequest, ResponseWithError{
+				Error: ResponseError{
+					Code:    "BAD_REQUEST",
+					Message: err.Error(),
+				},
+			})
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+func (h *TeamHandler) SetRequiredApprovals(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.SetRequiredApprovalsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	if !middleware.CurrentActor(c).CanManageTeam(req.TeamName) {
+		forbidden(c)
+
+		return
+	}
+
+	team, err := h.svc.SetRequiredApprovals(ctx, req.TeamName, *req.RequiredApprovals)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrTeamNotExist) {
+			c.JSON(http.StatusNotFound, ResponseWithError{
+				Error: ResponseError{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+
+			return
+		}
+
+		if errors.Is(err, apperrors.ErrApprovalsExceedReviewers) {
+			c.JSON(http.StatusBadRequest, ResponseWithError{
+				Error: ResponseError{
+					Code:    "BAD_REQUEST",
+					Message: err.Error(),
+				},
+			})
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+func (h *TeamHandler) SetMaxOpenReviews(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.SetTeamMaxOpenReviewsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	if !middleware.CurrentActor(c).CanManageTeam(req.TeamName) {
+		forbidden(c)
+
+		return
+	}
+
+	team, err := h.svc.SetMaxOpenReviews(ctx, req.TeamName, req.MaxOpenReviews)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrTeamNotExist) {
+			c.JSON(http.StatusNotFound, ResponseWithError{
+				Error: ResponseError{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+func (h *TeamHandler) SetReviewSLA(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.SetReviewSLARequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	if !middleware.CurrentActor(c).CanManageTeam(req.TeamName) {
+		forbidden(c)
+
+		return
+	}
+
+	team, err := h.svc.SetReviewSLA(ctx, req.TeamName, req.ReviewSLAMinutes, req.StaleReviewAction)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrTeamNotExist) {
+			c.JSON(http.StatusNotFound, ResponseWithError{
+				Error: ResponseError{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, team)
+}
+
+func (h *TeamHandler) AddMembers(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.TeamMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	a := middleware.CurrentActor(c)
+	if !a.CanManageTeam(req.TeamName) {
+		forbidden(c)
+
+		return
+	}
+
+	allowed, err := h.auth.CanChangeMembersActivity(ctx, a, req.Members)
+	if !authorized(c, allowed, err) {
+		return
+	}
+
+	response, err := h.svc.AddMembers(ctx, req.TeamName, req.Members)
+	if err != nil {
+		h.membershipError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *TeamHandler) RemoveMembers(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.RemoveTeamMembersRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	if !middleware.CurrentActor(c).CanManageTeam(req.TeamName) {
+		forbidden(c)
+
+		return
+	}
+
+	response, err := h.svc.RemoveMembers(ctx, req.TeamName, req.UserIDs)
+	if err != nil {
+		h.membershipError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *TeamHandler) MoveMember(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.MoveTeamMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	if a := middleware.CurrentActor(c); !a.CanManageTeam(req.FromTeam) || !a.CanManageTeam(req.ToTeam) {
+		forbidden(c)
+
+		return
+	}
+
+	response, err := h.svc.MoveMember(ctx, req.UserID, req.FromTeam, req.ToTeam)
+	if err != nil {
+		h.membershipError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *TeamHandler) membershipError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, apperrors.ErrTeamNotExist), errors.Is(err, apperrors.ErrUserNotExist):
+		c.JSON(http.StatusNotFound, ResponseWithError{
+			Error: ResponseError{
+				Code:    "NOT_FOUND",
+				Message: "resource not found",
+			},
+		})
+	case errors.Is(err, apperrors.ErrUserNotInTeam):
+		c.JSON(http.StatusConflict, ResponseWithError{
+			Error: ResponseError{
+				Code:    "NOT_TEAM_MEMBER",
+				Message: err.Error(),
+			},
+		})
+	default:
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+	}
+}
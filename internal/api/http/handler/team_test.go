@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/api/http/middleware"
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+)
+
+type fakeTeamService struct {
+	TeamService
+
+	err error
+}
+
+func (f *fakeTeamService) SetReviewersCount(_ context.Context, teamName string, _ int) (*model.TeamResponse, error) {
+	return &model.TeamResponse{TeamName: teamName}, f.err
+}
+
+func (f *fakeTeamService) SetRequiredApprovals(_ context.Context, teamName string, _ int) (*model.TeamResponse, error) {
+	return &model.TeamResponse{TeamName: teamName}, f.err
+}
+
+func TestTeamSettingsRejectUnmergeableApprovals(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		path string
+		body string
+	}{
+		{"/team/setReviewersCount", `{"team_name":"backend","reviewers_count":1}`},
+		{"/team/setRequiredApprovals", `{"team_name":"backend","required_approvals":3}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			h := NewTeamHandler(zap.NewNop(), &fakeTeamService{err: apperrors.ErrApprovalsExceedReviewers}, nil)
+
+			router := gin.New()
+			router.Use(middleware.Auth(zap.NewNop(), testActors))
+			router.POST("/team/setReviewersCount", h.SetReviewersCount)
+			router.POST("/team/setRequiredApprovals", h.SetRequiredApprovals)
+
+			rec := doJSON(router, tt.path, "lead", tt.body)
+			if rec.Code != http.StatusBadRequest || !strings.Contains(rec.Body.String(), "BAD_REQUEST") {
+				t.Fatalf("got %d %s, want 400 BAD_REQUEST", rec.Code, rec.Body)
+			}
+		})
+	}
+}
@@ -0,0 +1,145 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/api/http/middleware"
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+)
+
+type UnavailabilityService interface {
+	Create(ctx context.Context, req *model.CreateUnavailabilityRequest) (*model.CreateUnavailabilityResponse, error)
+	List(ctx context.Context, qp *model.UnavailabilityQueryParam) (*model.UnavailabilityListResponse, error)
+	Delete(ctx context.Context, id int64) error
+}
+
+type UnavailabilityHandler struct {
+	l    *zap.Logger
+	svc  UnavailabilityService
+	auth Authorizer
+}
+
+func NewUnavailabilityHandler(l *zap.Logger, svc UnavailabilityService, auth Authorizer) *UnavailabilityHandler {
+	return &UnavailabilityHandler{
+		l:    l,
+		svc:  svc,
+		auth: auth,
+	}
+}
+
+func (h *UnavailabilityHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.CreateUnavailabilityRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	if a := middleware.CurrentActor(c); a.ID != req.UserID {
+		allowed, err := h.auth.CanManageUser(ctx, a, req.UserID)
+		if !authorized(c, allowed, err) {
+			return
+		}
+	}
+
+	response, err := h.svc.Create(ctx, &req)
+	if err != nil {
+		h.notFoundOrInternal(c, err, apperrors.ErrUserNotExist)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, response)
+}
+
+func (h *UnavailabilityHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var qp model.UnavailabilityQueryParam
+	if err := c.ShouldBindQuery(&qp); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	response, err := h.svc.List(ctx, &qp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *UnavailabilityHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.UnavailabilityIDRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	allowed, err := h.auth.CanManageUnavailability(ctx, middleware.CurrentActor(c), req.ID)
+	if !authorized(c, allowed, err) {
+		return
+	}
+
+	if err := h.svc.Delete(ctx, req.ID); err != nil {
+		h.notFoundOrInternal(c, err, apperrors.ErrUnavailabilityNotExist)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *UnavailabilityHandler) notFoundOrInternal(c *gin.Context, err, notFound error) {
+	if errors.Is(err, notFound) {
+		c.JSON(http.StatusNotFound, ResponseWithError{
+			Error: ResponseError{
+				Code:    "NOT_FOUND",
+				Message: "resource not found",
+			},
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, ResponseWithError{
+		Error: ResponseError{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		},
+	})
+}
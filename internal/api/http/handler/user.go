@@ -8,24 +8,29 @@ import (
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"avito-test-assignment/internal/api/http/middleware"
 	"avito-test-assignment/internal/apperrors"
 	"avito-test-assignment/internal/model"
 )
 
 type UserService interface {
-	SetIsActive(ctx context.Context, userID string, isActive bool) (*model.UserResponseWithTeamName, error)
+	SetIsActive(ctx context.Context, userID string, isActive bool) (*model.UserResponseWithTeamName, *model.ReviewReassignments, error)
 	GetReview(ctx context.Context, userID string) (*model.GetReviewResponse, error)
+	BulkDeactivate(ctx context.Context, teamName string, userIDs []string) (*model.BulkDeactivateResponse, error)
+	SetMaxOpenReviews(ctx context.Context, userID string, limit *int) (*model.ReviewLoadResponse, error)
 }
 
 type UserHandler struct {
-	l   *zap.Logger
-	svc UserService
+	l    *zap.Logger
+	svc  UserService
+	auth Authorizer
 }
 
-func NewUserHandler(l *zap.Logger, svc UserService) *UserHandler {
+func NewUserHandler(l *zap.Logger, svc UserService, auth Authorizer) *UserHandler {
 	return &UserHandler{
-		l:   l,
-		svc: svc,
+		l:    l,
+		svc:  svc,
+		auth: auth,
 	}
 }
 
@@ -40,9 +45,16 @@ func (h *UserHandler) SetIsActive(c *gin.Context) {
 				Message: err.Error(),
 			},
 		})
+
+		return
+	}
+
+	allowed, err := h.auth.CanManageUser(ctx, middleware.CurrentActor(c), req.UserID)
+	if !authorized(c, allowed, err) {
+		return
 	}
 
-	user, err := h.svc.SetIsActive(ctx, req.UserID, req.IsActive)
+	user, reassigned, err := h.svc.SetIsActive(ctx, req.UserID, req.IsActive)
 	if err != nil {
 		if errors.Is(err, apperrors.ErrUserNotExist) {
 			c.JSON(http.StatusNotFound, ResponseWithError{
@@ -66,7 +78,9 @@ func (h *UserHandler) SetIsActive(c *gin.Context) {
 	}
 
 	c.JSON(http.StatusOK, ResponseWithUser{
-		User: user,
+		User:       user,
+		Reassigned: reassigned.Reassigned,
+		Uncovered:  reassigned.Uncovered,
 	})
 }
 
@@ -108,3 +122,107 @@ func (h *UserHandler) GetReview(c *gin.Context) {
 
 	c.JSON(http.StatusOK, prs)
 }
+
+func (h *UserHandler) BulkDeactivate(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.BulkDeactivateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	a := middleware.CurrentActor(c)
+
+	if req.TeamName != "" && !a.CanManageTeam(req.TeamName) {
+		forbidden(c)
+
+		return
+	}
+
+	for _, userID := range req.UserIDs {
+		allowed, err := h.auth.CanManageUser(ctx, a, userID)
+		if !authorized(c, allowed, err) {
+			return
+		}
+	}
+
+	response, err := h.svc.BulkDeactivate(ctx, req.TeamName, req.UserIDs)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotExist) || errors.Is(err, apperrors.ErrTeamNotExist) {
+			c.JSON(http.StatusNotFound, ResponseWithError{
+				Error: ResponseError{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *UserHandler) SetMaxOpenReviews(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.SetMaxOpenReviewsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	if a := middleware.CurrentActor(c); a.ID != req.UserID {
+		allowed, err := h.auth.CanManageUser(ctx, a, req.UserID)
+		if !authorized(c, allowed, err) {
+			return
+		}
+	}
+
+	response, err := h.svc.SetMaxOpenReviews(ctx, req.UserID, req.MaxOpenReviews)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotExist) {
+			c.JSON(http.StatusNotFound, ResponseWithError{
+				Error: ResponseError{
+					Code:    "NOT_FOUND",
+					Message: "resource not found",
+				},
+			})
+
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, response)
+}
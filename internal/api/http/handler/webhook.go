@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+)
+
+type WebhookService interface {
+	Create(ctx context.Context, url, secret string, events []string) (*model.WebhookSubscription, error)
+	List(ctx context.Context) (*model.WebhookListResponse, error)
+	Delete(ctx context.Context, id int64) error
+	DeadLetters(ctx context.Context, qp *model.DeadLettersQueryParam) (*model.DeadLettersResponse, error)
+	Redeliver(ctx context.Context, deliveryID int64) error
+}
+
+type WebhookHandler struct {
+	l   *zap.Logger
+	svc WebhookService
+}
+
+func NewWebhookHandler(l *zap.Logger, svc WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		l:   l,
+		svc: svc,
+	}
+}
+
+func (h *WebhookHandler) Create(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.CreateWebhookRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	sub, err := h.svc.Create(ctx, req.URL, req.Secret, req.Events)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, sub)
+}
+
+func (h *WebhookHandler) List(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	subs, err := h.svc.List(ctx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, subs)
+}
+
+func (h *WebhookHandler) Delete(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.WebhookIDRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	if err := h.svc.Delete(ctx, req.ID); err != nil {
+		h.notFoundOrInternal(c, err, apperrors.ErrWebhookNotExist)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *WebhookHandler) DeadLetters(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var qp model.DeadLettersQueryParam
+	if err := c.ShouldBindQuery(&qp); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	deliveries, err := h.svc.DeadLetters(ctx, &qp)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, ResponseWithError{
+			Error: ResponseError{
+				Code:    "INTERNAL_ERROR",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusOK, deliveries)
+}
+
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var req model.WebhookIDRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ResponseWithError{
+			Error: ResponseError{
+				Code:    "BAD_REQUEST",
+				Message: err.Error(),
+			},
+		})
+
+		return
+	}
+
+	if err := h.svc.Redeliver(ctx, req.ID); err != nil {
+		h.notFoundOrInternal(c, err, apperrors.ErrWebhookDeliveryNotExist)
+
+		return
+	}
+
+	c.Status(http.StatusAccepted)
+}
+
+func (h *WebhookHandler) notFoundOrInternal(c *gin.Context, err, notFound error) {
+	if errors.Is(err, notFound) {
+		c.JSON(http.StatusNotFound, ResponseWithError{
+			Error: ResponseError{
+				Code:    "NOT_FOUND",
+				Message: "resource not found",
+			},
+		})
+
+		return
+	}
+
+	c.JSON(http.StatusInternalServerError, ResponseWithError{
+		Error: ResponseError{
+			Code:    "INTERNAL_ERROR",
+			Message: err.Error(),
+		},
+	})
+}
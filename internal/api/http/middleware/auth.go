@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/actor"
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/tracing"
+)
+
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*actor.Actor, error)
+}
+
+// Auth passes through requests already authenticated by JWT.
+func Auth(l *zap.Logger, auth Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get(actorKey); ok {
+			c.Next()
+
+			return
+		}
+
+		if authenticate(c, l, auth, bearerToken(c)) {
+			c.Next()
+		}
+	}
+}
+
+func bearerToken(c *gin.Context) string {
+	token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !ok {
+		return ""
+	}
+
+	return strings.TrimSpace(token)
+}
+
+func authenticate(c *gin.Context, l *zap.Logger, auth Authenticator, token string) bool {
+	a, err := auth.Authenticate(c.Request.Context(), token)
+	if err != nil {
+		if !errors.Is(err, apperrors.ErrUnauthorized) {
+			l.Error("Failed to authenticate request", append(tracing.LogFields(c.Request.Context()), zap.Error(err))...)
+
+			abortWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+
+			return false
+		}
+
+		c.Header("WWW-Authenticate", `Bearer realm="api"`)
+		abortWithError(c, http.StatusUnauthorized, "UNAUTHORIZED", err.Error())
+
+		return false
+	}
+
+	setActor(c, a)
+
+	return true
+}
+
+func RequireRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !CurrentActor(c).HasRole(roles...) {
+			abortWithError(c, http.StatusForbidden, "FORBIDDEN", "action is not allowed for the actor role")
+
+			return
+		}
+
+		c.Next()
+	}
+}
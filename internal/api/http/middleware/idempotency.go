@@ -0,0 +1,186 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/model"
+	"avito-test-assignment/internal/repository"
+)
+
+const (
+	IdempotencyKeyHeader      = "Idempotency-Key"
+	IdempotencyReplayedHeader = "Idempotent-Replayed"
+
+	DefaultIdempotencyTTL   = 24 * time.Hour
+	DefaultIdempotencyLease = time.Minute
+
+	maxIdempotencyKeyLength = 255
+)
+
+type IdempotencyStore interface {
+	Reserve(
+		ctx context.Context,
+		ext repository.RepoExtension,
+		key, route, actorID, requestHash string,
+		ttl, lease time.Duration,
+	) (*model.IdempotencyRecord, bool, error)
+	Complete(ctx context.Context, ext repository.RepoExtension, key, route, actorID string, statusCode int, body []byte) error
+	Release(ctx context.Context, ext repository.RepoExtension, key, route, actorID string) error
+}
+
+type errorResponse struct {
+	Error errorBody `json:"error"`
+}
+
+type errorBody struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type recordingWriter struct {
+	gin.ResponseWriter
+
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(b []byte) (int, error) {
+	w.body.Write(b)
+
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *recordingWriter) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+
+	return w.ResponseWriter.WriteString(s)
+}
+
+// Idempotency holds a key in progress for at most lease, so a crashed request does not block retries until the TTL.
+func Idempotency(log *zap.Logger, store IdempotencyStore, ttl, lease time.Duration) gin.HandlerFunc {
+	if ttl <= 0 {
+		ttl = DefaultIdempotencyTTL
+	}
+
+	if lease <= 0 {
+		lease = DefaultIdempotencyLease
+	}
+
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" {
+			c.Next()
+
+			return
+		}
+
+		if len(key) > maxIdempotencyKeyLength {
+			abortWithError(c, http.StatusBadRequest, "BAD_REQUEST", "Idempotency-Key is too long")
+
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			abortWithError(c, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+
+			return
+		}
+
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		route := c.FullPath()
+		actorID := CurrentActor(c).ID
+		hash := requestHash(body)
+
+		record, reserved, err := store.Reserve(c.Request.Context(), nil, key, route, actorID, hash, ttl, lease)
+		if err != nil {
+			abortWithError(c, http.StatusInternalServerError, "INTERNAL_ERROR", err.Error())
+
+			return
+		}
+
+		if !reserved {
+			replay(c, record, hash)
+
+			return
+		}
+
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+
+		finished := false
+
+		// Runs while a handler panic unwinds as well, before the recovery middleware answers 500.
+		defer func() {
+			// The request context may already be cancelled by the timeout middleware.
+			ctx := context.WithoutCancel(c.Request.Context())
+			status := writer.Status()
+
+			if !finished || status >= http.StatusInternalServerError {
+				if err := store.Release(ctx, nil, key, route, actorID); err != nil {
+					log.Warn("Failed to release idempotency key", zap.String("key", key), zap.Error(err))
+				}
+
+				return
+			}
+
+			if err := store.Complete(ctx, nil, key, route, actorID, status, writer.body.Bytes()); err != nil {
+				log.Warn("Failed to store idempotent response", zap.String("key", key), zap.Error(err))
+			}
+		}()
+
+		c.Next()
+
+		finished = true
+	}
+}
+
+func replay(c *gin.Context, record *model.IdempotencyRecord, hash string) {
+	if record.RequestHash != hash {
+		abortWithError(c, http.StatusUnprocessableEntity, "IDEMPOTENCY_KEY_REUSED",
+			"Idempotency-Key was already used with a different payload")
+
+		return
+	}
+
+	if record.StatusCode == nil {
+		abortWithError(c, http.StatusConflict, "IDEMPOTENCY_IN_PROGRESS",
+			"a request with this Idempotency-Key is still being processed")
+
+		return
+	}
+
+	c.Header(IdempotencyReplayedHeader, "true")
+	c.Data(*record.StatusCode, "application/json; charset=utf-8", record.ResponseBody)
+	c.Abort()
+}
+
+func requestHash(body []byte) string {
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, body); err == nil {
+		body = compacted.Bytes()
+	}
+
+	sum := sha256.Sum256(body)
+
+	return hex.EncodeToString(sum[:])
+}
+
+func abortWithError(c *gin.Context, status int, code, message string) {
+	c.AbortWithStatusJSON(status, errorResponse{
+		Error: errorBody{
+			Code:    code,
+			Message: message,
+		},
+	})
+}
@@ -0,0 +1,226 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/model"
+	"avito-test-assignment/internal/repository"
+)
+
+type fakeIdempotencyStore struct {
+	mu          sync.Mutex
+	records     map[string]*model.IdempotencyRecord
+	lockedUntil map[string]time.Time
+}
+
+func newFakeIdempotencyStore() *fakeIdempotencyStore {
+	return &fakeIdempotencyStore{
+		records:     map[string]*model.IdempotencyRecord{},
+		lockedUntil: map[string]time.Time{},
+	}
+}
+
+func (s *fakeIdempotencyStore) Reserve(
+	_ context.Context,
+	_ repository.RepoExtension,
+	key, route, actorID, requestHash string,
+	_, lease time.Duration,
+) (*model.IdempotencyRecord, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := key + "|" + route + "|" + actorID
+	if rec, ok := s.records[id]; ok && (rec.StatusCode != nil || time.Now().Before(s.lockedUntil[id])) {
+		return rec, false, nil
+	}
+
+	s.records[id] = &model.IdempotencyRecord{Key: key, Route: route, ActorID: actorID, RequestHash: requestHash}
+	s.lockedUntil[id] = time.Now().Add(lease)
+
+	return nil, true, nil
+}
+
+func (s *fakeIdempotencyStore) Complete(
+	_ context.Context,
+	_ repository.RepoExtension,
+	key, route, actorID string,
+	statusCode int,
+	body []byte,
+) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rec := s.records[key+"|"+route+"|"+actorID]
+	rec.StatusCode = &statusCode
+	rec.ResponseBody = body
+
+	return nil
+}
+
+func (s *fakeIdempotencyStore) Release(_ context.Context, _ repository.RepoExtension, key, route, actorID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key+"|"+route+"|"+actorID)
+
+	return nil
+}
+
+type idempotencyTest struct {
+	store  *fakeIdempotencyStore
+	router *gin.Engine
+	calls  int
+	status int
+	panics bool
+}
+
+func newIdempotencyTest() *idempotencyTest {
+	gin.SetMode(gin.TestMode)
+
+	tt := &idempotencyTest{store: newFakeIdempotencyStore(), status: http.StatusCreated}
+
+	tt.router = gin.New()
+	tt.router.Use(gin.CustomRecovery(func(c *gin.Context, _ any) {
+		c.AbortWithStatus(http.StatusInternalServerError)
+	}), Actor())
+	tt.router.POST("/pullRequest/create", Idempotency(zap.NewNop(), tt.store, time.Hour, time.Minute), func(c *gin.Context) {
+		tt.calls++
+		if tt.panics {
+			panic("handler failed")
+		}
+
+		c.JSON(tt.status, gin.H{"call": tt.calls})
+	})
+
+	return tt
+}
+
+func (tt *idempotencyTest) post(actorID, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/create", strings.NewReader(body))
+	req.Header.Set(ActorHeader, actorID)
+	req.Header.Set(IdempotencyKeyHeader, key)
+
+	rec := httptest.NewRecorder()
+	tt.router.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestIdempotencyReplaysStoredResponse(t *testing.T) {
+	tt := newIdempotencyTest()
+
+	first := tt.post("u1", "k1", `{"pull_request_id": "pr-1"}`)
+	second := tt.post("u1", "k1", `{"pull_request_id":"pr-1"}`)
+
+	if tt.calls != 1 {
+		t.Fatalf("handler called %d times, want 1", tt.calls)
+	}
+
+	if second.Code != first.Code || second.Body.String() != first.Body.String() {
+		t.Fatalf("replayed %d %s, want %d %s", second.Code, second.Body, first.Code, first.Body)
+	}
+
+	if second.Header().Get(IdempotencyReplayedHeader) != "true" || first.Header().Get(IdempotencyReplayedHeader) != "" {
+		t.Fatal("only the replayed response should carry the replay header")
+	}
+}
+
+func TestIdempotencyRejectsDifferentPayload(t *testing.T) {
+	tt := newIdempotencyTest()
+
+	tt.post("u1", "k1", `{"pull_request_id":"pr-1"}`)
+
+	rec := tt.post("u1", "k1", `{"pull_request_id":"pr-2"}`)
+	if rec.Code != http.StatusUnprocessableEntity || !strings.Contains(rec.Body.String(), "IDEMPOTENCY_KEY_REUSED") {
+		t.Fatalf("got %d %s, want 422 IDEMPOTENCY_KEY_REUSED", rec.Code, rec.Body)
+	}
+
+	if tt.calls != 1 {
+		t.Fatalf("handler called %d times, want 1", tt.calls)
+	}
+}
+
+func TestIdempotencyConflictsWhileInFlight(t *testing.T) {
+	tt := newIdempotencyTest()
+
+	body := `{"pull_request_id":"pr-1"}`
+	tt.store.records["k1|/pullRequest/create|u1"] = &model.IdempotencyRecord{RequestHash: requestHash([]byte(body))}
+	tt.store.lockedUntil["k1|/pullRequest/create|u1"] = time.Now().Add(time.Minute)
+
+	rec := tt.post("u1", "k1", body)
+	if rec.Code != http.StatusConflict || !strings.Contains(rec.Body.String(), "IDEMPOTENCY_IN_PROGRESS") {
+		t.Fatalf("got %d %s, want 409 IDEMPOTENCY_IN_PROGRESS", rec.Code, rec.Body)
+	}
+
+	if tt.calls != 0 {
+		t.Fatalf("handler called %d times while the key was in flight", tt.calls)
+	}
+}
+
+func TestIdempotencyReleasesKeyOnServerError(t *testing.T) {
+	tt := newIdempotencyTest()
+	tt.status = http.StatusInternalServerError
+
+	tt.post("u1", "k1", `{}`)
+
+	if len(tt.store.records) != 0 {
+		t.Fatalf("key was not released after 5xx: %+v", tt.store.records)
+	}
+
+	tt.status = http.StatusCreated
+
+	if rec := tt.post("u1", "k1", `{}`); rec.Code != http.StatusCreated || tt.calls != 2 {
+		t.Fatalf("retry got %d after %d calls, want 201 after 2", rec.Code, tt.calls)
+	}
+}
+
+func TestIdempotencyReleasesKeyOnPanic(t *testing.T) {
+	tt := newIdempotencyTest()
+	tt.panics = true
+
+	if rec := tt.post("u1", "k1", `{}`); rec.Code != http.StatusInternalServerError {
+		t.Fatalf("got %d, want 500 from recovery", rec.Code)
+	}
+
+	if len(tt.store.records) != 0 {
+		t.Fatalf("key was not released after a panic: %+v", tt.store.records)
+	}
+
+	tt.panics = false
+
+	if rec := tt.post("u1", "k1", `{}`); rec.Code != http.StatusCreated || tt.calls != 2 {
+		t.Fatalf("retry got %d after %d calls, want 201 after 2", rec.Code, tt.calls)
+	}
+}
+
+func TestIdempotencyTakesOverExpiredLease(t *testing.T) {
+	tt := newIdempotencyTest()
+
+	body := `{"pull_request_id":"pr-1"}`
+	tt.store.records["k1|/pullRequest/create|u1"] = &model.IdempotencyRecord{RequestHash: requestHash([]byte(body))}
+	tt.store.lockedUntil["k1|/pullRequest/create|u1"] = time.Now().Add(-time.Second)
+
+	if rec := tt.post("u1", "k1", body); rec.Code != http.StatusCreated || tt.calls != 1 {
+		t.Fatalf("got %d after %d calls, want 201 after 1", rec.Code, tt.calls)
+	}
+}
+
+func TestIdempotencyScopesKeysByActor(t *testing.T) {
+	tt := newIdempotencyTest()
+
+	tt.post("u1", "k1", `{}`)
+
+	rec := tt.post("u2", "k1", `{}`)
+	if tt.calls != 2 || rec.Header().Get(IdempotencyReplayedHeader) != "" {
+		t.Fatal("another actor's response was replayed for the same key")
+	}
+}
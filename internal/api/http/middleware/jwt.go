@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/jwt"
+)
+
+// JWT leaves tokens that are not JWTs for Auth, which must follow it in the chain.
+func JWT(l *zap.Logger, auth Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := bearerToken(c)
+		if !jwt.IsCompact(token) {
+			c.Next()
+
+			return
+		}
+
+		if authenticate(c, l, auth, token) {
+			c.Next()
+		}
+	}
+}
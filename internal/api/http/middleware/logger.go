@@ -7,6 +7,8 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/tracing"
 )
 
 func Logger(log *zap.Logger) gin.HandlerFunc {
@@ -18,12 +20,14 @@ func Logger(log *zap.Logger) gin.HandlerFunc {
 		latency := time.Since(startTime)
 		statusCode := c.Writer.Status()
 
-		log.Info("request",
+		fields := []zap.Field{
 			zap.String("method", c.Request.Method),
 			zap.String("uri", c.Request.URL.RequestURI()),
 			zap.Int("code", statusCode),
 			zap.String("status", http.StatusText(statusCode)),
-			zap.String("latency", fmt.Sprintf("%d µs", latency)),
-		)
+			zap.String("latency", fmt.Sprintf("%d µs", latency.Microseconds())),
+		}
+
+		log.Info("request", append(fields, tracing.LogFields(c.Request.Context())...)...)
 	}
 }
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RequestObserver interface {
+	ObserveRequest(method, route string, status int, latency time.Duration)
+}
+
+// Metrics labels requests with the route template, not the raw path, to bound label cardinality.
+func Metrics(m RequestObserver) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		startTime := time.Now()
+
+		c.Next()
+
+		m.ObserveRequest(c.Request.Method, c.FullPath(), c.Writer.Status(), time.Since(startTime))
+	}
+}
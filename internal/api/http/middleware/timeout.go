@@ -2,14 +2,26 @@ package middleware
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
-func RequestTimeout(timeout time.Duration) gin.HandlerFunc {
+// RequestTimeout takes per-prefix overrides because a child context cannot outlive its parent.
+func RequestTimeout(timeout time.Duration, overrides map[string]time.Duration) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		limit := timeout
+
+		for prefix, override := range overrides {
+			if override > 0 && strings.HasPrefix(c.FullPath(), prefix) {
+				limit = override
+
+				break
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), limit)
 		defer cancel()
 
 		c.Request = c.Request.WithContext(ctx)
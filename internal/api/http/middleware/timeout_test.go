@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var remaining time.Duration
+
+	deadline := func(c *gin.Context) {
+		d, _ := c.Request.Context().Deadline()
+		remaining = time.Until(d)
+	}
+
+	router := gin.New()
+	router.Use(RequestTimeout(3*time.Second, map[string]time.Duration{"/export": 5 * time.Minute}))
+	router.GET("/team/get", deadline)
+	router.GET("/export/pullRequests", deadline)
+
+	tests := []struct {
+		path string
+		want time.Duration
+	}{
+		{"/team/get", 3 * time.Second},
+		{"/export/pullRequests", 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+		if remaining > tt.want || remaining < tt.want-time.Second {
+			t.Errorf("%s: deadline in %v, want about %v", tt.path, remaining, tt.want)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+
+	"avito-test-assignment/internal/tracing"
+)
+
+func TestTracing(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	prevProvider, prevPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prevProvider)
+		otel.SetTextMapPropagator(prevPropagator)
+		_ = provider.Shutdown(context.Background())
+	})
+
+	gin.SetMode(gin.TestMode)
+
+	router := gin.New()
+	router.Use(Tracing())
+	router.POST("/pullRequest/reassign", func(c *gin.Context) {
+		_, span := tracing.Start(c.Request.Context(), "PullRequestService.Reassign")
+		span.End()
+
+		c.Status(http.StatusInternalServerError)
+	})
+
+	const (
+		traceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentSpanID = "00f067aa0ba902b7"
+	)
+
+	req := httptest.NewRequest(http.MethodPost, "/pullRequest/reassign", nil)
+	req.Header.Set("traceparent", "00-"+traceID+"-"+parentSpanID+"-01")
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+
+	service, server := spans[0], spans[1]
+
+	if server.Name != "POST /pullRequest/reassign" || server.SpanKind != trace.SpanKindServer {
+		t.Fatalf("unexpected server span %q kind %v", server.Name, server.SpanKind)
+	}
+
+	if server.SpanContext.TraceID().String() != traceID || server.Parent.SpanID().String() != parentSpanID {
+		t.Fatalf("server span does not continue the incoming trace: %s / %s", server.SpanContext.TraceID(), server.Parent.SpanID())
+	}
+
+	if service.Parent.SpanID() != server.SpanContext.SpanID() {
+		t.Fatal("service span is not a child of the server span")
+	}
+
+	if server.Status.Code != codes.Error {
+		t.Fatalf("5xx response not marked as error: %+v", server.Status)
+	}
+
+	want := "00-" + traceID + "-" + server.SpanContext.SpanID().String() + "-01"
+	if got := rec.Header().Get("traceparent"); got != want {
+		t.Fatalf("response traceparent %q, want %q", got, want)
+	}
+}
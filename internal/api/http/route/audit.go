@@ -0,0 +1,11 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"avito-test-assignment/internal/api/http/handler"
+)
+
+func RegisterAuditRoutes(g *gin.RouterGroup, h *handler.AuditHandler) {
+	g.GET("", h.List)
+}
@@ -0,0 +1,19 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"avito-test-assignment/internal/actor"
+	"avito-test-assignment/internal/api/http/handler"
+	"avito-test-assignment/internal/api/http/middleware"
+)
+
+func RegisterAuthRoutes(g *gin.RouterGroup, h *handler.AuthHandler) {
+	g.GET("/me", h.Me)
+
+	admin := g.Group("", middleware.RequireRole(actor.RoleAdmin))
+	admin.POST("/tokens/create", h.CreateToken)
+	admin.GET("/tokens/list", h.ListTokens)
+	admin.POST("/tokens/revoke", h.RevokeToken)
+	admin.POST("/setRole", h.SetRole)
+}
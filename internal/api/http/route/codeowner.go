@@ -0,0 +1,14 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"avito-test-assignment/internal/api/http/handler"
+)
+
+func RegisterCodeOwnerRoutes(g *gin.RouterGroup, h *handler.CodeOwnerHandler) {
+	g.POST("/add", h.Add)
+	g.GET("/list", h.List)
+	g.POST("/delete", h.Delete)
+	g.POST("/match", h.Match)
+}
@@ -0,0 +1,13 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"avito-test-assignment/internal/api/http/handler"
+)
+
+func RegisterExportRoutes(g *gin.RouterGroup, h *handler.ExportHandler) {
+	g.GET("/pullRequests", h.PullRequests)
+	g.GET("/assignments", h.Assignments)
+	g.GET("/stats", h.Stats)
+}
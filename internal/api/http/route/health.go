@@ -0,0 +1,12 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"avito-test-assignment/internal/api/http/handler"
+)
+
+func RegisterHealthRoutes(g gin.IRoutes, h *handler.HealthHandler) {
+	g.GET("/healthz", h.Healthz)
+	g.GET("/readyz", h.Readyz)
+}
@@ -6,8 +6,14 @@ import (
 	"avito-test-assignment/internal/api/http/handler"
 )
 
-func RegisterPRRoutes(g *gin.RouterGroup, h *handler.PullRequestHandler) {
-	g.POST("/create", h.Create)
-	g.POST("/merge", h.Merge)
-	g.POST("reassign", h.Reassign)
+func RegisterPRRoutes(g *gin.RouterGroup, h *handler.PullRequestHandler, idempotency gin.HandlerFunc) {
+	g.POST("/create", idempotency, h.Create)
+	g.POST("/merge", idempotency, h.Merge)
+	g.POST("reassign", idempotency, h.Reassign)
+	g.POST("/ready", h.Ready)
+	g.POST("/close", h.Close)
+	g.POST("/reopen", h.Reopen)
+	g.POST("/review", h.Review)
+	g.GET("/get", h.Get)
+	g.GET("/list", h.List)
 }
@@ -0,0 +1,22 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"avito-test-assignment/internal/actor"
+	"avito-test-assignment/internal/api/http/handler"
+	"avito-test-assignment/internal/api/http/middleware"
+)
+
+func RegisterReviewerPoolRoutes(g *gin.RouterGroup, h *handler.ReviewerPoolHandler) {
+	g.GET("/get", h.Get)
+	g.GET("/list", h.List)
+
+	admin := g.Group("", middleware.RequireRole(actor.RoleAdmin))
+	admin.POST("/create", h.Create)
+	admin.POST("/delete", h.Delete)
+	admin.POST("/addMembers", h.AddMembers)
+	admin.POST("/removeMembers", h.RemoveMembers)
+	admin.POST("/attach", h.Attach)
+	admin.POST("/detach", h.Detach)
+}
@@ -2,13 +2,17 @@ package route
 
 import (
 	"io"
+	"path"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 
+	"avito-test-assignment/internal/actor"
 	"avito-test-assignment/internal/api/http/handler"
 	"avito-test-assignment/internal/api/http/middleware"
 	"avito-test-assignment/internal/config"
+	"avito-test-assignment/internal/metrics"
 )
 
 func SetupRouter(
@@ -18,14 +22,44 @@ func SetupRouter(
 	userHdl *handler.UserHandler,
 	pullRequestHdl *handler.PullRequestHandler,
 	statsHdl *handler.StatsHandler,
+	auditHdl *handler.AuditHandler,
+	webhookHdl *handler.WebhookHandler,
+	unavailabilityHdl *handler.UnavailabilityHandler,
+	codeOwnerHdl *handler.CodeOwnerHandler,
+	reviewerPoolHdl *handler.ReviewerPoolHandler,
+	exportHdl *handler.ExportHandler,
+	authHdl *handler.AuthHandler,
+	healthHdl *handler.HealthHandler,
+	idempotencyStore middleware.IdempotencyStore,
+	authenticator middleware.Authenticator,
+	jwtAuthenticator middleware.Authenticator,
+	m *metrics.Metrics,
 ) *gin.Engine {
 	gin.SetMode(gin.ReleaseMode)
 	gin.DefaultWriter = io.Discard
 
 	router := gin.Default()
 
+	router.Use(middleware.Tracing())
 	router.Use(middleware.Logger(l))
-	router.Use(middleware.RequestTimeout(cfg.Timeout.Request))
+	router.Use(middleware.Metrics(m))
+	router.Use(middleware.RequestTimeout(cfg.Timeout.Request, map[string]time.Duration{
+		path.Join(cfg.BasePath, "/export"): cfg.Timeout.Export,
+	}))
+
+	// Registered before the auth middleware so scrapes and probes do not need a token.
+	router.GET("/metrics", gin.WrapH(m.Handler()))
+	RegisterHealthRoutes(router, healthHdl)
+
+	if cfg.Auth.Enabled {
+		if cfg.Auth.JWT.Enabled {
+			router.Use(middleware.JWT(l, jwtAuthenticator))
+		}
+
+		router.Use(middleware.Auth(l, authenticator))
+	} else {
+		router.Use(middleware.Actor())
+	}
 
 	router.HandleMethodNotAllowed = true
 	router.NoMethod(handler.NoMethod)
@@ -33,17 +67,40 @@ func SetupRouter(
 
 	basePath := router.Group(cfg.BasePath)
 
+	managers := middleware.RequireRole(actor.RoleAdmin, actor.RoleTeamLead)
+
+	authGroup := basePath.Group("/auth")
+	RegisterAuthRoutes(authGroup, authHdl)
+
 	teamGroup := basePath.Group("/team")
 	RegisterTeamRoutes(teamGroup, teamHdl)
 
+	codeOwnerGroup := teamGroup.Group("/codeOwners")
+	RegisterCodeOwnerRoutes(codeOwnerGroup, codeOwnerHdl)
+
 	usersGroup := basePath.Group("/users")
 	RegisterUsersRoutes(usersGroup, userHdl)
 
+	unavailabilityGroup := usersGroup.Group("/unavailability")
+	RegisterUnavailabilityRoutes(unavailabilityGroup, unavailabilityHdl)
+
+	reviewerPoolGroup := basePath.Group("/reviewerPools")
+	RegisterReviewerPoolRoutes(reviewerPoolGroup, reviewerPoolHdl)
+
 	prGroup := basePath.Group("/pullRequest")
-	RegisterPRRoutes(prGroup, pullRequestHdl)
+	RegisterPRRoutes(prGroup, pullRequestHdl, middleware.Idempotency(l, idempotencyStore, cfg.Idempotency.TTL, cfg.Idempotency.Lease))
 
 	statsGroup := basePath.Group("/stats")
 	RegisterStatsRoutes(statsGroup, statsHdl)
 
+	exportGroup := basePath.Group("/export", managers)
+	RegisterExportRoutes(exportGroup, exportHdl)
+
+	auditGroup := basePath.Group("/audit", managers)
+	RegisterAuditRoutes(auditGroup, auditHdl)
+
+	webhookGroup := basePath.Group("/webhooks", middleware.RequireRole(actor.RoleAdmin))
+	RegisterWebhookRoutes(webhookGroup, webhookHdl)
+
 	return router
 }
@@ -0,0 +1,126 @@
+package route
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/actor"
+	"avito-test-assignment/internal/api/http/handler"
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/config"
+	"avito-test-assignment/internal/metrics"
+)
+
+type fakeAuthenticator map[string]*actor.Actor
+
+func (f fakeAuthenticator) Authenticate(_ context.Context, token string) (*actor.Actor, error) {
+	a, ok := f[token]
+	if !ok {
+		return nil, apperrors.ErrUnauthorized
+	}
+
+	return a, nil
+}
+
+type fakeAuthorizer struct {
+	handler.Authorizer
+
+	prAuthors map[string]string
+}
+
+func (f fakeAuthorizer) CanManagePullRequest(_ context.Context, a *actor.Actor, pullRequestID string) (bool, error) {
+	return a.IsAdmin() || f.prAuthors[pullRequestID] == a.ID, nil
+}
+
+// newTestRouter passes nil services: every request here must be rejected before reaching one.
+func newTestRouter() http.Handler {
+	l := zap.NewNop()
+
+	cfg := &config.Config{}
+	cfg.Auth.Enabled = true
+
+	auth := fakeAuthenticator{
+		"member-token": {ID: "u1", Role: actor.RoleMember, Teams: []string{"backend"}},
+		"lead-token":   {ID: "u2", Role: actor.RoleTeamLead, Teams: []string{"backend"}},
+	}
+
+	return SetupRouter(l, cfg,
+		handler.NewTeamHandler(l, nil, nil),
+		handler.NewUserHandler(l, nil, nil),
+		handler.NewPullRequestHandler(l, nil, fakeAuthorizer{prAuthors: map[string]string{"pr-1": "u3"}}),
+		handler.NewStatsHandler(l, nil),
+		handler.NewAuditHandler(l, nil),
+		handler.NewWebhookHandler(l, nil),
+		handler.NewUnavailabilityHandler(l, nil, nil),
+		handler.NewCodeOwnerHandler(l, nil, nil),
+		handler.NewReviewerPoolHandler(l, nil),
+		handler.NewExportHandler(l, nil, nil),
+		handler.NewAuthHandler(l, nil),
+		handler.NewHealthHandler(l, nil),
+		nil, auth, nil, metrics.New(nil),
+	)
+}
+
+func TestAuthorization(t *testing.T) {
+	router := newTestRouter()
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		token  string
+		body   string
+		want   int
+	}{
+		{"no token", http.MethodGet, "/team/get?team_name=backend", "", "", http.StatusUnauthorized},
+		{"unknown token", http.MethodGet, "/team/get?team_name=backend", "bogus", "", http.StatusUnauthorized},
+		{"member on webhooks", http.MethodGet, "/webhooks/list", "member-token", "", http.StatusForbidden},
+		{"member on audit", http.MethodGet, "/audit", "member-token", "", http.StatusForbidden},
+		{"member on export", http.MethodGet, "/export/pullRequests", "member-token", "", http.StatusForbidden},
+		{"lead on webhooks", http.MethodGet, "/webhooks/list", "lead-token", "", http.StatusForbidden},
+		{"lead adding team", http.MethodPost, "/team/add", "lead-token", `{}`, http.StatusForbidden},
+		{
+			"lead editing another team", http.MethodPost, "/team/setReviewersCount", "lead-token",
+			`{"team_name":"frontend","reviewers_count":3}`, http.StatusForbidden,
+		},
+		{
+			"member editing own team", http.MethodPost, "/team/setReviewersCount", "member-token",
+			`{"team_name":"backend","reviewers_count":3}`, http.StatusForbidden,
+		},
+		{
+			"member creating PR for another author", http.MethodPost, "/pullRequest/create", "member-token",
+			`{"pull_request_id":"pr-2","pull_request_name":"Add search","author_id":"u3"}`, http.StatusForbidden,
+		},
+		{"member merging another's PR", http.MethodPost, "/pullRequest/merge", "member-token", `{"pull_request_id":"pr-1"}`, http.StatusForbidden},
+		{"member closing another's PR", http.MethodPost, "/pullRequest/close", "member-token", `{"pull_request_id":"pr-1"}`, http.StatusForbidden},
+		{"member readying another's PR", http.MethodPost, "/pullRequest/ready", "member-token", `{"pull_request_id":"pr-1"}`, http.StatusForbidden},
+		{"member reopening another's PR", http.MethodPost, "/pullRequest/reopen", "member-token", `{"pull_request_id":"pr-1"}`, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			if tt.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.want, rec.Body)
+			}
+
+			if tt.want == http.StatusUnauthorized && rec.Header().Get("WWW-Authenticate") == "" {
+				t.Fatal("401 without WWW-Authenticate header")
+			}
+		})
+	}
+}
@@ -8,4 +8,6 @@ import (
 
 func RegisterStatsRoutes(g *gin.RouterGroup, h *handler.StatsHandler) {
 	g.GET("", h.GetStats)
+	g.GET("/analytics", h.GetAnalytics)
+	g.GET("/fairness", h.GetFairness)
 }
@@ -3,10 +3,19 @@ package route
 import (
 	"github.com/gin-gonic/gin"
 
+	"avito-test-assignment/internal/actor"
 	"avito-test-assignment/internal/api/http/handler"
+	"avito-test-assignment/internal/api/http/middleware"
 )
 
 func RegisterTeamRoutes(g *gin.RouterGroup, h *handler.TeamHandler) {
-	g.POST("/add", h.AddTeam)
+	g.POST("/add", middleware.RequireRole(actor.RoleAdmin), h.AddTeam)
 	g.GET("/get", h.GetTeam)
+	g.POST("/setReviewersCount", h.SetReviewersCount)
+	g.POST("/setRequiredApprovals", h.SetRequiredApprovals)
+	g.POST("/setMaxOpenReviews", h.SetMaxOpenReviews)
+	g.POST("/setReviewSLA", h.SetReviewSLA)
+	g.POST("/addMembers", h.AddMembers)
+	g.POST("/removeMembers", h.RemoveMembers)
+	g.POST("/moveMember", h.MoveMember)
 }
@@ -0,0 +1,13 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"avito-test-assignment/internal/api/http/handler"
+)
+
+func RegisterUnavailabilityRoutes(g *gin.RouterGroup, h *handler.UnavailabilityHandler) {
+	g.POST("/create", h.Create)
+	g.GET("/list", h.List)
+	g.POST("/delete", h.Delete)
+}
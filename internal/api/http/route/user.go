@@ -9,4 +9,6 @@ import (
 func RegisterUsersRoutes(g *gin.RouterGroup, h *handler.UserHandler) {
 	g.POST("/setIsActive", h.SetIsActive)
 	g.GET("/getReview", h.GetReview)
+	g.POST("/setMaxOpenReviews", h.SetMaxOpenReviews)
+	g.POST("/bulkDeactivate", h.BulkDeactivate)
 }
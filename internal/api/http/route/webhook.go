@@ -0,0 +1,15 @@
+package route
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"avito-test-assignment/internal/api/http/handler"
+)
+
+func RegisterWebhookRoutes(g *gin.RouterGroup, h *handler.WebhookHandler) {
+	g.POST("/create", h.Create)
+	g.GET("/list", h.List)
+	g.POST("/delete", h.Delete)
+	g.GET("/deadLetters", h.DeadLetters)
+	g.POST("/redeliver", h.Redeliver)
+}
@@ -1,47 +1,102 @@
 package app
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"go.uber.org/zap"
 
 	"avito-test-assignment/internal/api/http/handler"
 	"avito-test-assignment/internal/api/http/route"
 	"avito-test-assignment/internal/config"
+	"avito-test-assignment/internal/jwt"
+	"avito-test-assignment/internal/metrics"
 	"avito-test-assignment/internal/repository"
 	"avito-test-assignment/internal/service"
+	"avito-test-assignment/internal/tracing"
 	"avito-test-assignment/pkg/postgres"
 	"avito-test-assignment/pkg/server"
 )
 
+const tracerShutdownTimeout = 5 * time.Second
+
 type App struct {
-	l          *zap.Logger
-	cfg        *config.Config
-	db         postgres.Postgres
-	httpServer server.HTTPServer
+	l            *zap.Logger
+	cfg          *config.Config
+	db           postgres.Postgres
+	httpServer   server.HTTPServer
+	dispatcher   *service.WebhookDispatcher
+	janitor      *service.IdempotencyJanitor
+	watcher      *service.UnavailabilityWatcher
+	escalator    *service.EscalationScheduler
+	jwksReloader *service.JWKSReloader
+	health       *service.HealthService
+	tracer       *sdktrace.TracerProvider
+
+	workersCtx  context.Context
+	stopWorkers context.CancelFunc
+	workersMu   sync.Mutex
+	workers     sync.WaitGroup
 }
 
 type Repository struct {
-	UserRepo        *repository.UserRepository
-	TeamRepo        *repository.TeamRepository
-	PullRequestRepo *repository.PullRequestRepository
+	UserRepo           *repository.UserRepository
+	TeamRepo           *repository.TeamRepository
+	PullRequestRepo    *repository.PullRequestRepository
+	AuditRepo          *repository.AuditRepository
+	WebhookRepo        *repository.WebhookRepository
+	IdempotencyRepo    *repository.IdempotencyRepository
+	UnavailabilityRepo *repository.UnavailabilityRepository
+	CodeOwnerRepo      *repository.CodeOwnerRepository
+	ReviewerPoolRepo   *repository.ReviewerPoolRepository
+	EscalationRepo     *repository.EscalationRepository
+	AnalyticsRepo      *repository.AnalyticsRepository
+	ExportRepo         *repository.ExportRepository
+	AuthRepo           *repository.AuthRepository
+	HealthRepo         *repository.HealthRepository
 }
 
 type Service struct {
-	TeamSvc        *service.TeamService
-	UserSvc        *service.UserService
-	PullRequestSvc *service.PullRequestService
-	StatsSvc       *service.StatsService
+	TeamSvc           *service.TeamService
+	UserSvc           *service.UserService
+	PullRequestSvc    *service.PullRequestService
+	StatsSvc          *service.StatsService
+	AuditSvc          *service.AuditService
+	WebhookSvc        *service.WebhookService
+	UnavailabilitySvc *service.UnavailabilityService
+	CodeOwnerSvc      *service.CodeOwnerService
+	ReviewerPoolSvc   *service.ReviewerPoolService
+	EscalationSvc     *service.EscalationService
+	ExportSvc         *service.ExportService
+	AuthSvc           *service.AuthService
+	HealthSvc         *service.HealthService
 }
 
 type Handler struct {
-	TeamHdl        *handler.TeamHandler
-	UserHdl        *handler.UserHandler
-	PullRequestHdl *handler.PullRequestHandler
-	StatsHdl       *handler.StatsHandler
+	TeamHdl           *handler.TeamHandler
+	UserHdl           *handler.UserHandler
+	PullRequestHdl    *handler.PullRequestHandler
+	StatsHdl          *handler.StatsHandler
+	AuditHdl          *handler.AuditHandler
+	WebhookHdl        *handler.WebhookHandler
+	UnavailabilityHdl *handler.UnavailabilityHandler
+	CodeOwnerHdl      *handler.CodeOwnerHandler
+	ReviewerPoolHdl   *handler.ReviewerPoolHandler
+	ExportHdl         *handler.ExportHandler
+	AuthHdl           *handler.AuthHandler
+	HealthHdl         *handler.HealthHandler
 }
 
 func New(l *zap.Logger, cfg *config.Config) (*App, error) {
+	tracer, err := initTracing(l, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize tracing: %w", err)
+	}
+
 	db, err := initDB(l, &cfg.Database)
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize database: %w", err)
@@ -49,17 +104,48 @@ func New(l *zap.Logger, cfg *config.Config) (*App, error) {
 
 	repo := initRepository(l, db)
 
-	svc := initService(l, repo)
+	m := metrics.New(db.Pool())
+
+	l.Debug("Metrics initialized")
+
+	svc, err := initService(l, cfg, repo, m)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize services: %w", err)
+	}
 
 	hdl := initHandler(l, svc)
 
-	httpServer := initHTTPServer(l, cfg, hdl)
+	jwtAuth, jwksReloader, err := initJWT(l, &cfg.Auth.JWT, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize jwt: %w", err)
+	}
+
+	httpServer := initHTTPServer(l, cfg, hdl, repo, svc, jwtAuth, m)
+
+	dispatcher := initDispatcher(l, &cfg.Webhook, repo)
+
+	janitor := service.NewIdempotencyJanitor(l, repo.IdempotencyRepo, cfg.Idempotency.CleanupInterval)
+
+	watcher := service.NewUnavailabilityWatcher(l, svc.UnavailabilitySvc, cfg.Unavailability.CheckInterval, cfg.Unavailability.BatchSize)
+
+	escalator := service.NewEscalationScheduler(l, svc.EscalationSvc, cfg.Escalation.CheckInterval, cfg.Escalation.BatchSize)
+
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
 
 	return &App{
-		cfg:        cfg,
-		l:          l,
-		db:         db,
-		httpServer: httpServer,
+		cfg:          cfg,
+		l:            l,
+		db:           db,
+		httpServer:   httpServer,
+		dispatcher:   dispatcher,
+		janitor:      janitor,
+		watcher:      watcher,
+		escalator:    escalator,
+		jwksReloader: jwksReloader,
+		health:       svc.HealthSvc,
+		tracer:       tracer,
+		workersCtx:   workersCtx,
+		stopWorkers:  stopWorkers,
 	}, nil
 }
 
@@ -76,6 +162,23 @@ func (a *App) Run() error {
 	errs := make(chan error, 1)
 	defer close(errs)
 
+	a.startWorker(a.dispatcher.Run)
+	a.l.Debug("Webhook dispatcher started")
+
+	a.startWorker(a.janitor.Run)
+	a.l.Debug("Idempotency janitor started")
+
+	a.startWorker(a.watcher.Run)
+	a.l.Debug("Unavailability watcher started")
+
+	a.startWorker(a.escalator.Run)
+	a.l.Debug("Escalation scheduler started")
+
+	if a.jwksReloader != nil {
+		a.startWorker(a.jwksReloader.Run)
+		a.l.Debug("JWKS reloader started")
+	}
+
 	go func() {
 		if err := a.httpServer.Run(); err != nil {
 			errs <- err
@@ -89,17 +192,72 @@ func (a *App) Run() error {
 	return nil
 }
 
+// Shutdown drains readiness first so the orchestrator stops routing traffic before the server stops.
 func (a *App) Shutdown() error {
+	a.health.Drain()
+	a.l.Debug("Readiness switched to draining", zap.Duration("drain_delay", a.cfg.Health.DrainDelay))
+
+	time.Sleep(a.cfg.Health.DrainDelay)
+
+	srvErr := a.httpServer.Shutdown()
+	if srvErr == nil {
+		a.l.Debug("HTTP server shutdown")
+	}
+
+	a.stopWorkers()
+
+	a.workersMu.Lock()
+	a.workers.Wait()
+	a.workersMu.Unlock()
+
+	a.l.Debug("Background workers stopped")
+
 	a.db.Close()
 	a.l.Debug("Database closed")
 
-	if srvErr := a.httpServer.Shutdown(); srvErr != nil {
-		return srvErr
+	var tracerErr error
+
+	if a.tracer != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), tracerShutdownTimeout)
+		defer cancel()
+
+		if err := a.tracer.Shutdown(ctx); err != nil {
+			tracerErr = fmt.Errorf("failed to shutdown tracer provider: %w", err)
+		} else {
+			a.l.Debug("Tracer provider shutdown")
+		}
+	}
+
+	return errors.Join(srvErr, tracerErr)
+}
+
+func (a *App) startWorker(run func(ctx context.Context)) {
+	a.workersMu.Lock()
+	defer a.workersMu.Unlock()
+
+	a.workers.Add(1)
+
+	go func() {
+		defer a.workers.Done()
+
+		run(a.workersCtx)
+	}()
+}
+
+func initTracing(l *zap.Logger, cfg *config.Config) (*sdktrace.TracerProvider, error) {
+	provider, err := tracing.Setup(context.Background(), &tracing.Config{
+		Enabled:     cfg.Tracing.Enabled,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	}, cfg.ServiceName)
+	if err != nil {
+		return nil, err
 	}
 
-	a.l.Debug("HTTP server shutdown")
+	l.Debug("Tracing initialized", zap.Bool("enabled", cfg.Tracing.Enabled))
 
-	return nil
+	return provider, nil
 }
 
 func initDB(l *zap.Logger, cfg *config.Database) (postgres.Postgres, error) {
@@ -116,6 +274,7 @@ func initDB(l *zap.Logger, cfg *config.Database) (postgres.Postgres, error) {
 			Path:      cfg.Migration.Path,
 			AutoApply: cfg.Migration.AutoApply,
 		},
+		Tracer: tracing.NewQueryTracer(),
 	}
 
 	db, err := postgres.New(postgresCfg)
@@ -141,47 +300,163 @@ func initRepository(l *zap.Logger, db postgres.Postgres) *Repository {
 
 	l.Debug("Pull request repository initialized")
 
+	auditRepo := repository.NewAuditRepository(db.Pool())
+
+	l.Debug("Audit repository initialized")
+
+	webhookRepo := repository.NewWebhookRepository(db.Pool())
+
+	l.Debug("Webhook repository initialized")
+
+	idempotencyRepo := repository.NewIdempotencyRepository(db.Pool())
+
+	l.Debug("Idempotency repository initialized")
+
+	unavailabilityRepo := repository.NewUnavailabilityRepository(db.Pool())
+
+	l.Debug("Unavailability repository initialized")
+
+	codeOwnerRepo := repository.NewCodeOwnerRepository(db.Pool())
+
+	l.Debug("Code owner repository initialized")
+
+	reviewerPoolRepo := repository.NewReviewerPoolRepository(db.Pool())
+
+	l.Debug("Reviewer pool repository initialized")
+
+	escalationRepo := repository.NewEscalationRepository(db.Pool())
+
+	l.Debug("Escalation repository initialized")
+
+	analyticsRepo := repository.NewAnalyticsRepository(db.Pool())
+
+	l.Debug("Analytics repository initialized")
+
+	exportRepo := repository.NewExportRepository(db.Pool())
+
+	l.Debug("Export repository initialized")
+
+	authRepo := repository.NewAuthRepository(db.Pool())
+
+	l.Debug("Auth repository initialized")
+
+	healthRepo := repository.NewHealthRepository(db.Pool())
+
+	l.Debug("Health repository initialized")
+
 	return &Repository{
-		UserRepo:        userRepo,
-		TeamRepo:        teamRepo,
-		PullRequestRepo: prRepo,
+		UserRepo:           userRepo,
+		TeamRepo:           teamRepo,
+		PullRequestRepo:    prRepo,
+		AuditRepo:          auditRepo,
+		WebhookRepo:        webhookRepo,
+		IdempotencyRepo:    idempotencyRepo,
+		UnavailabilityRepo: unavailabilityRepo,
+		CodeOwnerRepo:      codeOwnerRepo,
+		ReviewerPoolRepo:   reviewerPoolRepo,
+		EscalationRepo:     escalationRepo,
+		AnalyticsRepo:      analyticsRepo,
+		ExportRepo:         exportRepo,
+		AuthRepo:           authRepo,
+		HealthRepo:         healthRepo,
 	}
 }
 
-func initService(l *zap.Logger, repo *Repository) *Service {
-	teamSvc := service.NewTeamService(repo.TeamRepo, repo.UserRepo)
+func initService(l *zap.Logger, cfg *config.Config, repo *Repository, m *metrics.Metrics) (*Service, error) {
+	selector, err := service.NewReviewerSelector(&service.SelectorConfig{
+		Strategy: cfg.Review.Strategy,
+		Seed:     cfg.Review.Seed,
+		Weights:  cfg.Review.Weights,
+		Teams:    cfg.Review.Teams,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	l.Debug("Reviewer selector initialized", zap.String("strategy", cfg.Review.Strategy))
+
+	prSvc := service.NewPullRequestService(repo.PullRequestRepo, repo.UserRepo, repo.TeamRepo,
+		repo.CodeOwnerRepo, repo.ReviewerPoolRepo, repo.AuditRepo, repo.WebhookRepo, selector, m)
+
+	l.Debug("Pull request service initialized")
+
+	teamSvc := service.NewTeamService(repo.TeamRepo, repo.UserRepo, repo.AuditRepo, prSvc)
 
 	l.Debug("Team service initialized")
 
-	userSvc := service.NewUserService(repo.TeamRepo, repo.UserRepo, repo.PullRequestRepo)
+	userSvc := service.NewUserService(repo.TeamRepo, repo.UserRepo, repo.PullRequestRepo, repo.AuditRepo, prSvc)
 
 	l.Debug("User service initialized")
 
-	prSvc := service.NewPullRequestService(repo.PullRequestRepo, repo.UserRepo, repo.TeamRepo)
+	statsSvc := service.NewStatsService(repo.PullRequestRepo, repo.EscalationRepo, repo.AnalyticsRepo)
 
-	l.Debug("Pull request service initialized")
+	l.Debug("Stats service initialized")
 
-	statsSvc := service.NewStatsService(repo.PullRequestRepo)
+	auditSvc := service.NewAuditService(repo.AuditRepo)
 
-	l.Debug("Stats service initialized")
+	l.Debug("Audit service initialized")
 
-	return &Service{
-		TeamSvc:        teamSvc,
-		UserSvc:        userSvc,
-		PullRequestSvc: prSvc,
-		StatsSvc:       statsSvc,
+	webhookSvc := service.NewWebhookService(repo.WebhookRepo)
+
+	l.Debug("Webhook service initialized")
+
+	unavailabilitySvc := service.NewUnavailabilityService(repo.UnavailabilityRepo, repo.UserRepo, repo.AuditRepo, prSvc)
+
+	l.Debug("Unavailability service initialized")
+
+	codeOwnerSvc := service.NewCodeOwnerService(repo.CodeOwnerRepo, repo.TeamRepo, repo.UserRepo, repo.AuditRepo)
+
+	l.Debug("Code owner service initialized")
+
+	reviewerPoolSvc := service.NewReviewerPoolService(repo.ReviewerPoolRepo, repo.TeamRepo, repo.UserRepo, repo.AuditRepo)
+
+	l.Debug("Reviewer pool service initialized")
+
+	escalationSvc := service.NewEscalationService(repo.EscalationRepo, prSvc)
+
+	l.Debug("Escalation service initialized")
+
+	exportSvc := service.NewExportService(repo.ExportRepo)
+
+	l.Debug("Export service initialized")
+
+	authSvc := service.NewAuthService(repo.AuthRepo, repo.AuditRepo, cfg.Auth.AdminTokens)
+
+	l.Debug("Auth service initialized")
+
+	healthSvc, err := service.NewHealthService(repo.HealthRepo, cfg.Database.Migration.Path)
+	if err != nil {
+		return nil, err
 	}
+
+	l.Debug("Health service initialized")
+
+	return &Service{
+		TeamSvc:           teamSvc,
+		UserSvc:           userSvc,
+		PullRequestSvc:    prSvc,
+		StatsSvc:          statsSvc,
+		AuditSvc:          auditSvc,
+		WebhookSvc:        webhookSvc,
+		UnavailabilitySvc: unavailabilitySvc,
+		CodeOwnerSvc:      codeOwnerSvc,
+		ReviewerPoolSvc:   reviewerPoolSvc,
+		EscalationSvc:     escalationSvc,
+		ExportSvc:         exportSvc,
+		AuthSvc:           authSvc,
+		HealthSvc:         healthSvc,
+	}, nil
 }
 
 func initHandler(l *zap.Logger, svc *Service) *Handler {
-	teamHdl := handler.NewTeamHandler(l, svc.TeamSvc)
+	teamHdl := handler.NewTeamHandler(l, svc.TeamSvc, svc.AuthSvc)
 	l.Debug("Team handler initialized")
 
-	userHdl := handler.NewUserHandler(l, svc.UserSvc)
+	userHdl := handler.NewUserHandler(l, svc.UserSvc, svc.AuthSvc)
 
 	l.Debug("User handler initialized")
 
-	prHdl := handler.NewPullRequestHandler(l, svc.PullRequestSvc)
+	prHdl := handler.NewPullRequestHandler(l, svc.PullRequestSvc, svc.AuthSvc)
 
 	l.Debug("Pull request handler initialized")
 
@@ -189,16 +464,83 @@ func initHandler(l *zap.Logger, svc *Service) *Handler {
 
 	l.Debug("Stats handler initialized")
 
+	auditHdl := handler.NewAuditHandler(l, svc.AuditSvc)
+
+	l.Debug("Audit handler initialized")
+
+	webhookHdl := handler.NewWebhookHandler(l, svc.WebhookSvc)
+
+	l.Debug("Webhook handler initialized")
+
+	unavailabilityHdl := handler.NewUnavailabilityHandler(l, svc.UnavailabilitySvc, svc.AuthSvc)
+
+	l.Debug("Unavailability handler initialized")
+
+	codeOwnerHdl := handler.NewCodeOwnerHandler(l, svc.CodeOwnerSvc, svc.AuthSvc)
+
+	l.Debug("Code owner handler initialized")
+
+	reviewerPoolHdl := handler.NewReviewerPoolHandler(l, svc.ReviewerPoolSvc)
+
+	l.Debug("Reviewer pool handler initialized")
+
+	exportHdl := handler.NewExportHandler(l, svc.ExportSvc, svc.StatsSvc)
+
+	l.Debug("Export handler initialized")
+
+	authHdl := handler.NewAuthHandler(l, svc.AuthSvc)
+
+	l.Debug("Auth handler initialized")
+
+	healthHdl := handler.NewHealthHandler(l, svc.HealthSvc)
+
+	l.Debug("Health handler initialized")
+
 	return &Handler{
-		TeamHdl:        teamHdl,
-		UserHdl:        userHdl,
-		PullRequestHdl: prHdl,
-		StatsHdl:       statsHdl,
+		TeamHdl:           teamHdl,
+		UserHdl:           userHdl,
+		PullRequestHdl:    prHdl,
+		StatsHdl:          statsHdl,
+		AuditHdl:          auditHdl,
+		WebhookHdl:        webhookHdl,
+		UnavailabilityHdl: unavailabilityHdl,
+		CodeOwnerHdl:      codeOwnerHdl,
+		ReviewerPoolHdl:   reviewerPoolHdl,
+		ExportHdl:         exportHdl,
+		AuthHdl:           authHdl,
+		HealthHdl:         healthHdl,
 	}
 }
 
-func initHTTPServer(l *zap.Logger, cfg *config.Config, hdl *Handler) server.HTTPServer {
-	router := route.SetupRouter(l, cfg, hdl.TeamHdl, hdl.UserHdl, hdl.PullRequestHdl, hdl.StatsHdl)
+func initHTTPServer(
+	l *zap.Logger,
+	cfg *config.Config,
+	hdl *Handler,
+	repo *Repository,
+	svc *Service,
+	jwtAuth *service.JWTAuthenticator,
+	m *metrics.Metrics,
+) server.HTTPServer {
+	router := route.SetupRouter(
+		l,
+		cfg,
+		hdl.TeamHdl,
+		hdl.UserHdl,
+		hdl.PullRequestHdl,
+		hdl.StatsHdl,
+		hdl.AuditHdl,
+		hdl.WebhookHdl,
+		hdl.UnavailabilityHdl,
+		hdl.CodeOwnerHdl,
+		hdl.ReviewerPoolHdl,
+		hdl.ExportHdl,
+		hdl.AuthHdl,
+		hdl.HealthHdl,
+		repo.IdempotencyRepo,
+		svc.AuthSvc,
+		jwtAuth,
+		m,
+	)
 
 	httpServer := server.NewHTTPServer(
 		server.WithAddr(cfg.HTTPServer.Host, cfg.HTTPServer.Port),
@@ -208,3 +550,44 @@ func initHTTPServer(l *zap.Logger, cfg *config.Config, hdl *Handler) server.HTTP
 
 	return httpServer
 }
+
+func initJWT(l *zap.Logger, cfg *config.JWT, repo *Repository) (*service.JWTAuthenticator, *service.JWKSReloader, error) {
+	if !cfg.Enabled {
+		return nil, nil, nil
+	}
+
+	keys := jwt.NewKeySet(cfg.JWKS)
+
+	if _, err := keys.Reload(context.Background()); err != nil {
+		return nil, nil, fmt.Errorf("failed to load jwks: %w", err)
+	}
+
+	l.Debug("JWKS loaded", zap.String("source", cfg.JWKS))
+
+	verifier := jwt.NewVerifier(keys, jwt.Options{
+		Issuer:   cfg.Issuer,
+		Audience: cfg.Audience,
+		Leeway:   cfg.Leeway,
+	})
+
+	authenticator := service.NewJWTAuthenticator(verifier, repo.AuthRepo, cfg.UserClaim, cfg.RoleClaim)
+
+	l.Debug("JWT authenticator initialized")
+
+	return authenticator, service.NewJWKSReloader(l, keys, cfg.ReloadInterval), nil
+}
+
+func initDispatcher(l *zap.Logger, cfg *config.Webhook, repo *Repository) *service.WebhookDispatcher {
+	dispatcher := service.NewWebhookDispatcher(l, repo.WebhookRepo, service.DispatcherConfig{
+		PollInterval:   cfg.PollInterval,
+		RequestTimeout: cfg.RequestTimeout,
+		BatchSize:      cfg.BatchSize,
+		MaxAttempts:    cfg.MaxAttempts,
+		BackoffBase:    cfg.BackoffBase,
+		BackoffMax:     cfg.BackoffMax,
+	})
+
+	l.Debug("Webhook dispatcher initialized")
+
+	return dispatcher
+}
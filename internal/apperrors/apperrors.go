@@ -7,12 +7,39 @@ import (
 var (
 	ErrTeamNotExist      = errors.New("team does not exist")
 	ErrTeamAlreadyExists = errors.New("team already exists")
+	ErrTeamAmbiguous     = errors.New("user belongs to several teams, team must be specified")
+	ErrUserNotInTeam     = errors.New("user is not a member of the team")
+
+	ErrApprovalsExceedReviewers = errors.New("required approvals cannot exceed reviewers count")
 
 	ErrUserNotExist = errors.New("user does not exist")
 
 	ErrPullRequestAlreadyExists     = errors.New("pull request already exists")
 	ErrPullRequestNotExist          = errors.New("pull request does not exist")
 	ErrPullRequestAlreadyMerged     = errors.New("pull request already merged")
-	ErrNoActiveReplacementCandidate = errors.New("no active replacement candidate in team")
+	ErrPullRequestClosed            = errors.New("pull request is closed")
+	ErrPullRequestIsDraft           = errors.New("pull request is a draft")
+	ErrInvalidStatusTransition      = errors.New("invalid pull request status transition")
+	ErrPullRequestStatusChanged     = errors.New("pull request status changed concurrently")
+	ErrNoActiveReplacementCandidate = errors.New("no available replacement candidate in team or its reviewer pools")
 	ErrUserIsNotAssignedAsReviewer  = errors.New("user is not assigned as reviewer on pr")
+	ErrNotEnoughApprovals           = errors.New("pull request does not have enough approvals")
+
+	ErrInvalidCursor    = errors.New("invalid pagination cursor")
+	ErrInvalidTimeRange = errors.New("from must be before to")
+
+	ErrWebhookNotExist         = errors.New("webhook subscription does not exist")
+	ErrWebhookDeliveryNotExist = errors.New("dead webhook delivery does not exist")
+
+	ErrUnavailabilityNotExist = errors.New("unavailability period does not exist")
+
+	ErrCodeOwnerRuleNotExist   = errors.New("code owner rule does not exist")
+	ErrInvalidCodeOwnerPattern = errors.New("invalid code owner pattern")
+
+	ErrReviewerPoolNotExist      = errors.New("reviewer pool does not exist")
+	ErrReviewerPoolAlreadyExists = errors.New("reviewer pool already exists")
+	ErrReviewerPoolNotAttached   = errors.New("reviewer pool is not attached to the team")
+
+	ErrUnauthorized     = errors.New("missing or invalid bearer token")
+	ErrAPITokenNotExist = errors.New("api token does not exist")
 )
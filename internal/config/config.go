@@ -14,10 +14,18 @@ import (
 var ErrConfigPathIsEmpty = errors.New("config path is empty")
 
 type Config struct {
-	App        `yaml:"app"`
-	Logger     `yaml:"log"`
-	Database   `yaml:"database"`
-	HTTPServer `yaml:"http_server"`
+	App            `yaml:"app"`
+	Logger         `yaml:"log"`
+	Database       `yaml:"database"`
+	HTTPServer     `yaml:"http_server"`
+	Review         `yaml:"review"`
+	Webhook        `yaml:"webhook"`
+	Idempotency    `yaml:"idempotency"`
+	Unavailability `yaml:"unavailability"`
+	Escalation     `yaml:"escalation"`
+	Auth           `yaml:"auth"`
+	Tracing        `yaml:"tracing"`
+	Health         `yaml:"health"`
 }
 
 type App struct {
@@ -61,8 +69,69 @@ type HTTPServer struct {
 	Timeout  Timeout `yaml:"timeout"`
 }
 
+type Review struct {
+	Strategy string            `yaml:"strategy"`
+	Seed     uint64            `yaml:"seed"`
+	Weights  map[string]int    `yaml:"weights"`
+	Teams    map[string]string `yaml:"teams"`
+}
+
+type Webhook struct {
+	PollInterval   time.Duration `yaml:"poll_interval"`
+	RequestTimeout time.Duration `yaml:"request_timeout"`
+	BatchSize      int           `yaml:"batch_size"`
+	MaxAttempts    int           `yaml:"max_attempts"`
+	BackoffBase    time.Duration `yaml:"backoff_base"`
+	BackoffMax     time.Duration `yaml:"backoff_max"`
+}
+
+type Idempotency struct {
+	TTL             time.Duration `yaml:"ttl"`
+	Lease           time.Duration `yaml:"lease"`
+	CleanupInterval time.Duration `yaml:"cleanup_interval"`
+}
+
+type Unavailability struct {
+	CheckInterval time.Duration `yaml:"check_interval"`
+	BatchSize     int           `yaml:"batch_size"`
+}
+
+type Escalation struct {
+	CheckInterval time.Duration `yaml:"check_interval"`
+	BatchSize     int           `yaml:"batch_size"`
+}
+
+type Auth struct {
+	Enabled     bool     `yaml:"enabled"`
+	AdminTokens []string `env:"AUTH_ADMIN_TOKENS" env-separator:"," yaml:"-"`
+	JWT         JWT      `yaml:"jwt"`
+}
+
+type JWT struct {
+	Enabled        bool          `yaml:"enabled"`
+	JWKS           string        `yaml:"jwks"`
+	ReloadInterval time.Duration `yaml:"reload_interval"`
+	Issuer         string        `yaml:"issuer"`
+	Audience       string        `yaml:"audience"`
+	Leeway         time.Duration `yaml:"leeway"`
+	UserClaim      string        `yaml:"user_claim"`
+	RoleClaim      string        `yaml:"role_claim"`
+}
+
+type Tracing struct {
+	Enabled     bool    `yaml:"enabled"`
+	Endpoint    string  `yaml:"endpoint"`
+	Insecure    bool    `yaml:"insecure"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+type Health struct {
+	DrainDelay time.Duration `yaml:"drain_delay"`
+}
+
 type Timeout struct {
 	Request time.Duration `yaml:"request"`
+	Export  time.Duration `yaml:"export"`
 	Read    time.Duration `yaml:"read"`
 	Write   time.Duration `yaml:"write"`
 	Idle    time.Duration `yaml:"idle"`
@@ -0,0 +1,180 @@
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+
+	flushEvery = 100
+)
+
+type Column[T any] struct {
+	Name  string
+	Value func(T) string
+}
+
+type Encoder[T any] struct {
+	w       *bufio.Writer
+	flusher http.Flusher
+	format  string
+	columns []Column[T]
+	csv     *csv.Writer
+	json    *json.Encoder
+	started bool
+	rows    int
+}
+
+func NewEncoder[T any](w io.Writer, format string, columns []Column[T]) *Encoder[T] {
+	buf := bufio.NewWriter(w)
+	flusher, _ := w.(http.Flusher)
+
+	e := &Encoder[T]{
+		w:       buf,
+		flusher: flusher,
+		format:  format,
+		columns: columns,
+	}
+
+	if format == FormatCSV {
+		e.csv = csv.NewWriter(buf)
+	} else {
+		e.json = json.NewEncoder(buf)
+	}
+
+	return e
+}
+
+func (e *Encoder[T]) Encode(record T) error {
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	if e.csv != nil {
+		values := make([]string, len(e.columns))
+		for i, c := range e.columns {
+			values[i] = c.Value(record)
+		}
+
+		if err := e.csv.Write(values); err != nil {
+			return err
+		}
+	} else if err := e.json.Encode(record); err != nil {
+		return err
+	}
+
+	e.rows++
+	if e.rows%flushEvery == 0 {
+		return e.flush()
+	}
+
+	return nil
+}
+
+// Close writes the CSV header even if no record was encoded.
+func (e *Encoder[T]) Close() error {
+	if err := e.start(); err != nil {
+		return err
+	}
+
+	return e.flush()
+}
+
+func (e *Encoder[T]) start() error {
+	if e.started {
+		return nil
+	}
+
+	e.started = true
+
+	if e.csv == nil {
+		return nil
+	}
+
+	header := make([]string, len(e.columns))
+	for i, c := range e.columns {
+		header[i] = c.Name
+	}
+
+	return e.csv.Write(header)
+}
+
+func (e *Encoder[T]) flush() error {
+	if e.csv != nil {
+		e.csv.Flush()
+
+		if err := e.csv.Error(); err != nil {
+			return err
+		}
+	}
+
+	if err := e.w.Flush(); err != nil {
+		return err
+	}
+
+	if e.flusher != nil {
+		e.flusher.Flush()
+	}
+
+	return nil
+}
+
+// Negotiate prefers the format parameter over Accept; CSV is the default.
+func Negotiate(format, accept string) string {
+	if format != "" {
+		return format
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		switch mediaType {
+		case "text/csv":
+			return FormatCSV
+		case "application/x-ndjson", "application/jsonl", "application/jsonlines":
+			return FormatNDJSON
+		}
+	}
+
+	return FormatCSV
+}
+
+func ContentType(format string) string {
+	if format == FormatNDJSON {
+		return "application/x-ndjson"
+	}
+
+	return "text/csv; charset=utf-8"
+}
+
+func FileName(name, format string) string {
+	if format == FormatNDJSON {
+		return name + ".ndjson"
+	}
+
+	return name + ".csv"
+}
+
+func Time(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
+
+func List(values []string) string {
+	return strings.Join(values, ";")
+}
@@ -0,0 +1,92 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+)
+
+type record struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+var columns = []Column[record]{
+	{Name: "id", Value: func(r record) string { return r.ID }},
+	{Name: "name", Value: func(r record) string { return r.Name }},
+}
+
+func TestEncoder_CSV(t *testing.T) {
+	var buf bytes.Buffer
+
+	enc := NewEncoder(&buf, FormatCSV, columns)
+
+	for _, r := range []record{{"1", "plain"}, {"2", "with, comma"}} {
+		if err := enc.Encode(r); err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	want := "id,name\n1,plain\n2,\"with, comma\"\n"
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestEncoder_EmptyCSVHasHeader(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := NewEncoder(&buf, FormatCSV, columns).Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	if buf.String() != "id,name\n" {
+		t.Fatalf("got %q", buf.String())
+	}
+}
+
+func TestEncoder_NDJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	enc := NewEncoder(&buf, FormatNDJSON, columns)
+
+	for _, r := range []record{{"1", "a"}, {"2", "b"}} {
+		if err := enc.Encode(r); err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+	}
+
+	if err := enc.Close(); err != nil {
+		t.Fatalf("close: %v", err)
+	}
+
+	want := "{\"id\":\"1\",\"name\":\"a\"}\n{\"id\":\"2\",\"name\":\"b\"}\n"
+	if buf.String() != want {
+		t.Fatalf("got %q, want %q", buf.String(), want)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := []struct {
+		format string
+		accept string
+		want   string
+	}{
+		{"", "", FormatCSV},
+		{"", "*/*", FormatCSV},
+		{"", "application/x-ndjson", FormatNDJSON},
+		{"", "text/html, application/jsonl;q=0.9", FormatNDJSON},
+		{"", "text/csv; charset=utf-8", FormatCSV},
+		{FormatCSV, "application/x-ndjson", FormatCSV},
+		{FormatNDJSON, "text/csv", FormatNDJSON},
+	}
+
+	for _, tt := range tests {
+		if got := Negotiate(tt.format, tt.accept); got != tt.want {
+			t.Errorf("Negotiate(%q, %q) = %q, want %q", tt.format, tt.accept, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	fetchTimeout = 10 * time.Second
+	maxJWKSSize  = 1 << 20
+)
+
+var ErrNoKeys = errors.New("jwks has no supported signing keys")
+
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type publicKey struct {
+	alg string
+	key crypto.PublicKey
+}
+
+type KeySet struct {
+	source string
+	client *http.Client
+
+	mu      sync.RWMutex
+	keys    map[string]publicKey
+	modTime time.Time
+	size    int64
+	etag    string
+}
+
+func NewKeySet(source string) *KeySet {
+	return &KeySet{
+		source: source,
+		client: &http.Client{Timeout: fetchTimeout},
+	}
+}
+
+func (s *KeySet) isURL() bool {
+	return strings.HasPrefix(s.source, "http://") || strings.HasPrefix(s.source, "https://")
+}
+
+// Reload keeps the previous keys on error.
+func (s *KeySet) Reload(ctx context.Context) (bool, error) {
+	if s.isURL() {
+		return s.reloadURL(ctx)
+	}
+
+	return s.reloadFile()
+}
+
+func (s *KeySet) reloadFile() (bool, error) {
+	info, err := os.Stat(s.source)
+	if err != nil {
+		return false, fmt.Errorf("failed to stat jwks file: %w", err)
+	}
+
+	s.mu.RLock()
+	unchanged := s.keys != nil && info.ModTime().Equal(s.modTime) && info.Size() == s.size
+	s.mu.RUnlock()
+
+	if unchanged {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(s.source)
+	if err != nil {
+		return false, fmt.Errorf("failed to read jwks file: %w", err)
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	s.keys, s.modTime, s.size = keys, info.ModTime(), info.Size()
+	s.mu.Unlock()
+
+	return true, nil
+}
+
+func (s *KeySet) reloadURL(ctx context.Context) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.source, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to build jwks request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	s.mu.RLock()
+	if s.keys != nil && s.etag != "" {
+		req.Header.Set("If-None-Match", s.etag)
+	}
+	s.mu.RUnlock()
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch jwks: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return false, nil
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("failed to fetch jwks: unexpected status %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxJWKSSize))
+	if err != nil {
+		return false, fmt.Errorf("failed to read jwks: %w", err)
+	}
+
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return false, err
+	}
+
+	s.mu.Lock()
+	s.keys, s.etag = keys, resp.Header.Get("ETag")
+	s.mu.Unlock()
+
+	return true, nil
+}
+
+// key accepts a token without kid only when the set holds exactly one key.
+func (s *KeySet) key(kid string) (publicKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if kid == "" && len(s.keys) == 1 {
+		for _, k := range s.keys {
+			return k, true
+		}
+	}
+
+	k, ok := s.keys[kid]
+
+	return k, ok
+}
+
+func parseJWKS(data []byte) (map[string]publicKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to decode jwks: %w", err)
+	}
+
+	keys := make(map[string]publicKey, len(set.Keys))
+
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid jwk %q: %w", k.Kid, err)
+		}
+
+		if key.key != nil {
+			keys[k.Kid] = key
+		}
+	}
+
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	return keys, nil
+}
+
+// publicKey returns a nil key for types other than RSA and P-256.
+func (k *jwk) publicKey() (publicKey, error) {
+	switch {
+	case k.Kty == "RSA" && (k.Alg == "" || k.Alg == AlgRS256):
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return publicKey{}, err
+		}
+
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return publicKey{}, err
+		}
+
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return publicKey{}, errors.New("invalid rsa exponent")
+		}
+
+		return publicKey{alg: AlgRS256, key: &rsa.PublicKey{N: n, E: int(e.Int64())}}, nil
+	case k.Kty == "EC" && k.Crv == "P-256" && (k.Alg == "" || k.Alg == AlgES256):
+		x, err := decodeCoordinate(k.X)
+		if err != nil {
+			return publicKey{}, err
+		}
+
+		y, err := decodeCoordinate(k.Y)
+		if err != nil {
+			return publicKey{}, err
+		}
+
+		key, err := ecdsa.ParseUncompressedPublicKey(ecdsaCurve, append(append([]byte{4}, x...), y...))
+		if err != nil {
+			return publicKey{}, fmt.Errorf("invalid ec point: %w", err)
+		}
+
+		return publicKey{alg: AlgES256, key: key}, nil
+	default:
+		return publicKey{}, nil
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		return nil, errors.New("invalid base64url integer")
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+func decodeCoordinate(s string) ([]byte, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || len(b) != es256CoordinateSize {
+		return nil, errors.New("invalid ec coordinate")
+	}
+
+	return b, nil
+}
@@ -0,0 +1,222 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	AlgRS256 = "RS256"
+	AlgES256 = "ES256"
+
+	es256CoordinateSize = 32
+)
+
+var ecdsaCurve = elliptic.P256()
+
+var (
+	ErrMalformed        = errors.New("malformed token")
+	ErrUnsupportedAlg   = errors.New("unsupported signing algorithm")
+	ErrUnknownKey       = errors.New("unknown signing key")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrExpired          = errors.New("token is expired")
+	ErrNotYetValid      = errors.New("token is not valid yet")
+	ErrInvalidIssuer    = errors.New("invalid issuer")
+	ErrInvalidAudience  = errors.New("invalid audience")
+)
+
+type Claims map[string]any
+
+// Lookup walks nested objects for a dotted name such as "realm_access.role".
+func (c Claims) Lookup(name string) (any, bool) {
+	var value any = map[string]any(c)
+
+	for part := range strings.SplitSeq(name, ".") {
+		obj, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+
+		if value, ok = obj[part]; !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+func (c Claims) Strings(name string) []string {
+	value, ok := c.Lookup(name)
+	if !ok {
+		return nil
+	}
+
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		result := make([]string, 0, len(v))
+
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+
+		return result
+	default:
+		return nil
+	}
+}
+
+type Options struct {
+	Issuer   string
+	Audience string
+	Leeway   time.Duration
+}
+
+type Verifier struct {
+	keys *KeySet
+	opts Options
+	now  func() time.Time
+}
+
+func NewVerifier(keys *KeySet, opts Options) *Verifier {
+	return &Verifier{
+		keys: keys,
+		opts: opts,
+		now:  time.Now,
+	}
+}
+
+func IsCompact(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// Verify requires exp; iss and aud are checked only when configured.
+func (v *Verifier) Verify(token string) (Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformed
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, err
+	}
+
+	if header.Alg != AlgRS256 && header.Alg != AlgES256 {
+		return nil, fmt.Errorf("%w: %q", ErrUnsupportedAlg, header.Alg)
+	}
+
+	key, ok := v.keys.key(header.Kid)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKey, header.Kid)
+	}
+
+	if key.alg != header.Alg {
+		return nil, fmt.Errorf("%w: %s key used with %s", ErrUnsupportedAlg, key.alg, header.Alg)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrMalformed
+	}
+
+	if !verifySignature(key, parts[0]+"."+parts[1], signature) {
+		return nil, ErrInvalidSignature
+	}
+
+	var claims Claims
+
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, err
+	}
+
+	if err := v.validate(claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func (v *Verifier) validate(claims Claims) error {
+	now := v.now()
+
+	exp, ok := claims["exp"].(float64)
+	if !ok {
+		return fmt.Errorf("%w: missing exp", ErrMalformed)
+	}
+
+	if now.After(unixTime(exp).Add(v.opts.Leeway)) {
+		return ErrExpired
+	}
+
+	if nbf, ok := claims["nbf"].(float64); ok && now.Add(v.opts.Leeway).Before(unixTime(nbf)) {
+		return ErrNotYetValid
+	}
+
+	if v.opts.Issuer != "" {
+		if iss, _ := claims["iss"].(string); iss != v.opts.Issuer {
+			return ErrInvalidIssuer
+		}
+	}
+
+	if v.opts.Audience != "" && !slices.Contains(claims.Strings("aud"), v.opts.Audience) {
+		return ErrInvalidAudience
+	}
+
+	return nil
+}
+
+func verifySignature(key publicKey, signed string, signature []byte) bool {
+	digest := sha256.Sum256([]byte(signed))
+
+	switch k := key.key.(type) {
+	case *rsa.PublicKey:
+		return rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature) == nil
+	case *ecdsa.PublicKey:
+		if len(signature) != 2*es256CoordinateSize {
+			return false
+		}
+
+		r := new(big.Int).SetBytes(signature[:es256CoordinateSize])
+		s := new(big.Int).SetBytes(signature[es256CoordinateSize:])
+
+		return ecdsa.Verify(k, digest[:], r, s)
+	default:
+		return false
+	}
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return ErrMalformed
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %w", ErrMalformed, err)
+	}
+
+	return nil
+}
+
+func unixTime(seconds float64) time.Time {
+	return time.Unix(int64(seconds), 0)
+}
@@ -0,0 +1,293 @@
+package jwt
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type testKeys struct {
+	rsa *rsa.PrivateKey
+	ec  *ecdsa.PrivateKey
+}
+
+func newTestKeys(t *testing.T) testKeys {
+	t.Helper()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate rsa key: %v", err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generate ec key: %v", err)
+	}
+
+	return testKeys{rsa: rsaKey, ec: ecKey}
+}
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func (k testKeys) jwks(t *testing.T) []byte {
+	t.Helper()
+
+	ecPub, err := k.ec.PublicKey.Bytes()
+	if err != nil {
+		t.Fatalf("encode ec key: %v", err)
+	}
+
+	data, err := json.Marshal(map[string]any{"keys": []map[string]string{
+		{"kty": "RSA", "kid": "rsa-1", "use": "sig", "n": b64(k.rsa.N.Bytes()), "e": b64(big.NewInt(int64(k.rsa.E)).Bytes())},
+		{"kty": "EC", "kid": "ec-1", "crv": "P-256", "x": b64(ecPub[1:33]), "y": b64(ecPub[33:])},
+		{"kty": "oct", "kid": "hmac", "k": "c2VjcmV0"},
+	}})
+	if err != nil {
+		t.Fatalf("marshal jwks: %v", err)
+	}
+
+	return data
+}
+
+func (k testKeys) sign(t *testing.T, alg, kid string, claims map[string]any) string {
+	t.Helper()
+
+	header, _ := json.Marshal(map[string]string{"alg": alg, "kid": kid, "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+	signed := b64(header) + "." + b64(payload)
+	digest := sha256.Sum256([]byte(signed))
+
+	var signature []byte
+
+	switch alg {
+	case AlgRS256:
+		sig, err := rsa.SignPKCS1v15(rand.Reader, k.rsa, crypto.SHA256, digest[:])
+		if err != nil {
+			t.Fatalf("sign rs256: %v", err)
+		}
+
+		signature = sig
+	case AlgES256:
+		r, s, err := ecdsa.Sign(rand.Reader, k.ec, digest[:])
+		if err != nil {
+			t.Fatalf("sign es256: %v", err)
+		}
+
+		signature = append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+	default:
+		signature = []byte("unsigned")
+	}
+
+	return signed + "." + b64(signature)
+}
+
+func writeJWKS(t *testing.T, path string, data []byte, modTime time.Time) {
+	t.Helper()
+
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("write jwks: %v", err)
+	}
+
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("touch jwks: %v", err)
+	}
+}
+
+func newFileVerifier(t *testing.T, keys testKeys, opts Options) *Verifier {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, keys.jwks(t), time.Now())
+
+	set := NewKeySet(path)
+	if _, err := set.Reload(context.Background()); err != nil {
+		t.Fatalf("load jwks: %v", err)
+	}
+
+	return NewVerifier(set, opts)
+}
+
+func TestVerify(t *testing.T) {
+	keys := newTestKeys(t)
+	other := newTestKeys(t)
+	now := time.Now()
+
+	v := newFileVerifier(t, keys, Options{Issuer: "https://sso.example.com", Audience: "reviewer", Leeway: time.Minute})
+
+	claims := func(overrides map[string]any) map[string]any {
+		c := map[string]any{
+			"sub": "u1",
+			"iss": "https://sso.example.com",
+			"aud": []string{"reviewer", "other"},
+			"exp": now.Add(time.Hour).Unix(),
+		}
+
+		for k, val := range overrides {
+			if val == nil {
+				delete(c, k)
+			} else {
+				c[k] = val
+			}
+		}
+
+		return c
+	}
+
+	tampered := keys.sign(t, AlgRS256, "rsa-1", claims(nil))
+	tampered = tampered[:len(tampered)-4] + "AAAA"
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{"rs256", keys.sign(t, AlgRS256, "rsa-1", claims(nil)), nil},
+		{"es256", keys.sign(t, AlgES256, "ec-1", claims(nil)), nil},
+		{"expired within leeway", keys.sign(t, AlgES256, "ec-1", claims(map[string]any{"exp": now.Add(-30 * time.Second).Unix()})), nil},
+		{"expired", keys.sign(t, AlgRS256, "rsa-1", claims(map[string]any{"exp": now.Add(-time.Hour).Unix()})), ErrExpired},
+		{"missing exp", keys.sign(t, AlgRS256, "rsa-1", claims(map[string]any{"exp": nil})), ErrMalformed},
+		{"not yet valid", keys.sign(t, AlgRS256, "rsa-1", claims(map[string]any{"nbf": now.Add(time.Hour).Unix()})), ErrNotYetValid},
+		{"wrong issuer", keys.sign(t, AlgRS256, "rsa-1", claims(map[string]any{"iss": "https://evil.example.com"})), ErrInvalidIssuer},
+		{"wrong audience", keys.sign(t, AlgRS256, "rsa-1", claims(map[string]any{"aud": "other"})), ErrInvalidAudience},
+		{"foreign key", other.sign(t, AlgRS256, "rsa-1", claims(nil)), ErrInvalidSignature},
+		{"tampered", tampered, ErrInvalidSignature},
+		{"unknown kid", keys.sign(t, AlgRS256, "rsa-2", claims(nil)), ErrUnknownKey},
+		{"alg none", keys.sign(t, "none", "rsa-1", claims(nil)), ErrUnsupportedAlg},
+		{"hmac key", keys.sign(t, "HS256", "hmac", claims(nil)), ErrUnsupportedAlg},
+		{"alg mismatch", keys.sign(t, AlgES256, "rsa-1", claims(nil)), ErrUnsupportedAlg},
+		{"malformed", "not.a.jwt", ErrMalformed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := v.Verify(tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Verify() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr == nil && got.Strings("sub")[0] != "u1" {
+				t.Fatalf("unexpected claims %v", got)
+			}
+		})
+	}
+}
+
+func TestClaimsLookup(t *testing.T) {
+	claims := Claims{
+		"sub":          "u1",
+		"realm_access": map[string]any{"roles": []any{"member", 42, "team-lead"}},
+	}
+
+	if got := claims.Strings("realm_access.roles"); len(got) != 2 || got[0] != "member" || got[1] != "team-lead" {
+		t.Fatalf("nested roles = %v", got)
+	}
+
+	if got := claims.Strings("sub.value"); got != nil {
+		t.Fatalf("lookup through a string = %v, want nil", got)
+	}
+
+	if got := claims.Strings("missing"); got != nil {
+		t.Fatalf("missing claim = %v, want nil", got)
+	}
+}
+
+func TestKeySetReloadFile(t *testing.T) {
+	first, second := newTestKeys(t), newTestKeys(t)
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	modTime := time.Now().Add(-time.Hour)
+
+	writeJWKS(t, path, first.jwks(t), modTime)
+
+	set := NewKeySet(path)
+	v := NewVerifier(set, Options{})
+
+	if changed, err := set.Reload(context.Background()); err != nil || !changed {
+		t.Fatalf("initial load: changed=%v err=%v", changed, err)
+	}
+
+	if changed, err := set.Reload(context.Background()); err != nil || changed {
+		t.Fatalf("reload of unchanged file: changed=%v err=%v", changed, err)
+	}
+
+	token := second.sign(t, AlgES256, "ec-1", map[string]any{"sub": "u1", "exp": time.Now().Add(time.Hour).Unix()})
+
+	if _, err := v.Verify(token); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("token of rotated key before reload: %v", err)
+	}
+
+	writeJWKS(t, path, second.jwks(t), modTime.Add(time.Minute))
+
+	if changed, err := set.Reload(context.Background()); err != nil || !changed {
+		t.Fatalf("reload of rotated file: changed=%v err=%v", changed, err)
+	}
+
+	if _, err := v.Verify(token); err != nil {
+		t.Fatalf("token of rotated key after reload: %v", err)
+	}
+
+	writeJWKS(t, path, []byte(`{"keys":[]}`), modTime.Add(2*time.Minute))
+
+	if _, err := set.Reload(context.Background()); !errors.Is(err, ErrNoKeys) {
+		t.Fatalf("reload of empty set: %v", err)
+	}
+
+	if _, err := v.Verify(token); err != nil {
+		t.Fatalf("previous keys must stay after a failed reload: %v", err)
+	}
+}
+
+func TestKeySetReloadURL(t *testing.T) {
+	keys := newTestKeys(t)
+	body := keys.jwks(t)
+	requests := 0
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write(body)
+	}))
+	defer srv.Close()
+
+	set := NewKeySet(srv.URL)
+
+	if changed, err := set.Reload(context.Background()); err != nil || !changed {
+		t.Fatalf("initial fetch: changed=%v err=%v", changed, err)
+	}
+
+	if changed, err := set.Reload(context.Background()); err != nil || changed {
+		t.Fatalf("conditional fetch: changed=%v err=%v", changed, err)
+	}
+
+	if requests != 2 {
+		t.Fatalf("expected 2 requests, got %d", requests)
+	}
+
+	token := keys.sign(t, AlgRS256, "rsa-1", map[string]any{"sub": "u1", "exp": time.Now().Add(time.Hour).Unix()})
+
+	if _, err := NewVerifier(set, Options{}).Verify(token); err != nil {
+		t.Fatalf("Verify() = %v", err)
+	}
+}
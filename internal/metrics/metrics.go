@@ -0,0 +1,105 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	namespace = "reviewer"
+
+	UnmatchedRoute = "unmatched"
+)
+
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requestDuration     *prometheus.HistogramVec
+	pullRequestsCreated prometheus.Counter
+	pullRequestsMerged  prometheus.Counter
+	reassignments       *prometheus.CounterVec
+	assignmentFailures  *prometheus.CounterVec
+}
+
+func New(pool *pgxpool.Pool) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by method, route and status code.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		pullRequestsCreated: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pull_requests_created_total",
+			Help:      "Pull requests created.",
+		}),
+		pullRequestsMerged: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "pull_requests_merged_total",
+			Help:      "Pull requests merged.",
+		}),
+		reassignments: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "reassignments_total",
+			Help:      "Reviewers replaced on open pull requests by reason.",
+		}, []string{"reason"}),
+		assignmentFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "assignment_failures_total",
+			Help:      "Reviewer assignments that found no available candidate (NO_CANDIDATE) by operation.",
+		}, []string{"operation"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.requestDuration,
+		m.pullRequestsCreated,
+		m.pullRequestsMerged,
+		m.reassignments,
+		m.assignmentFailures,
+	)
+
+	if pool != nil {
+		m.registry.MustRegister(newPoolCollector(pool))
+	}
+
+	return m
+}
+
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{Registry: m.registry})
+}
+
+func (m *Metrics) ObserveRequest(method, route string, status int, latency time.Duration) {
+	if route == "" {
+		route = UnmatchedRoute
+	}
+
+	m.requestDuration.WithLabelValues(method, route, strconv.Itoa(status)).Observe(latency.Seconds())
+}
+
+func (m *Metrics) PullRequestCreated() {
+	m.pullRequestsCreated.Inc()
+}
+
+func (m *Metrics) PullRequestMerged() {
+	m.pullRequestsMerged.Inc()
+}
+
+func (m *Metrics) ReviewerReassigned(reason string) {
+	m.reassignments.WithLabelValues(reason).Inc()
+}
+
+func (m *Metrics) AssignmentFailed(operation string) {
+	m.assignmentFailures.WithLabelValues(operation).Inc()
+}
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("scrape status %d", rec.Code)
+	}
+
+	body, err := io.ReadAll(rec.Body)
+	if err != nil {
+		t.Fatalf("read scrape: %v", err)
+	}
+
+	return string(body)
+}
+
+func TestMetricsExposition(t *testing.T) {
+	m := New(nil)
+
+	m.ObserveRequest(http.MethodPost, "/pullRequest/create", http.StatusCreated, 20*time.Millisecond)
+	m.ObserveRequest(http.MethodGet, "", http.StatusNotFound, time.Millisecond)
+	m.PullRequestCreated()
+	m.PullRequestCreated()
+	m.PullRequestMerged()
+	m.ReviewerReassigned("manual")
+	m.AssignmentFailed("reassign")
+
+	body := scrape(t, m)
+
+	for _, want := range []string{
+		`reviewer_http_request_duration_seconds_count{method="POST",route="/pullRequest/create",status="201"} 1`,
+		`reviewer_http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`,
+		`reviewer_pull_requests_created_total 2`,
+		`reviewer_pull_requests_merged_total 1`,
+		`reviewer_reassignments_total{reason="manual"} 1`,
+		`reviewer_assignment_failures_total{operation="reassign"} 1`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("scrape does not contain %q", want)
+		}
+	}
+
+	if strings.Contains(body, "reviewer_db_pool_") {
+		t.Error("pool metrics exposed without a pool")
+	}
+}
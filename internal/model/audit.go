@@ -0,0 +1,77 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	AuditPullRequestCreated  = "PR_CREATED"
+	AuditReviewersAssigned   = "REVIEWERS_ASSIGNED"
+	AuditReviewerReassigned  = "REVIEWER_REASSIGNED"
+	AuditReviewerRemoved     = "REVIEWER_REMOVED"
+	AuditReviewerAdded       = "REVIEWER_ADDED"
+	AuditPullRequestMerged   = "PR_MERGED"
+	AuditPullRequestStatus   = "PR_STATUS_CHANGED"
+	AuditReviewSubmitted     = "REVIEW_SUBMITTED"
+	AuditUserActivityChanged = "USER_ACTIVITY_CHANGED"
+	AuditUserSettingsChanged = "USER_SETTINGS_CHANGED"
+	AuditTeamCreated         = "TEAM_CREATED"
+	AuditTeamSettingsChanged = "TEAM_SETTINGS_CHANGED"
+	AuditTeamMemberAdded     = "TEAM_MEMBER_ADDED"
+	AuditTeamMemberRemoved   = "TEAM_MEMBER_REMOVED"
+	AuditTeamMemberMoved     = "TEAM_MEMBER_MOVED"
+
+	AuditUnavailabilityAdded   = "USER_UNAVAILABILITY_ADDED"
+	AuditUnavailabilityRemoved = "USER_UNAVAILABILITY_REMOVED"
+	AuditCodeOwnerRuleAdded    = "CODE_OWNER_RULE_ADDED"
+	AuditCodeOwnerRuleRemoved  = "CODE_OWNER_RULE_REMOVED"
+	AuditReviewerPoolCreated   = "REVIEWER_POOL_CREATED"
+	AuditReviewerPoolDeleted   = "REVIEWER_POOL_DELETED"
+	AuditReviewerPoolChanged   = "REVIEWER_POOL_CHANGED"
+	AuditUserRoleChanged       = "USER_ROLE_CHANGED"
+	AuditAPITokenCreated       = "API_TOKEN_CREATED"
+	AuditAPITokenRevoked       = "API_TOKEN_REVOKED"
+)
+
+type AuditEvent struct {
+	ID            int64           `json:"id"`
+	Actor         string          `json:"actor"`
+	Action        string          `json:"action"`
+	PullRequestID string          `json:"pull_request_id,omitempty"`
+	UserID        string          `json:"user_id,omitempty"`
+	TeamName      string          `json:"team_name,omitempty"`
+	Before        json.RawMessage `json:"before,omitempty"`
+	After         json.RawMessage `json:"after,omitempty"`
+	CreatedAt     time.Time       `json:"created_at"`
+}
+
+type AuditQueryParam struct {
+	PullRequestID string     `form:"pull_request_id"`
+	UserID        string     `form:"user_id"`
+	TeamName      string     `form:"team_name"`
+	Actor         string     `form:"actor"`
+	Action        string     `form:"action"`
+	From          *time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To            *time.Time `form:"to"   time_format:"2006-01-02T15:04:05Z07:00"`
+	Cursor        string     `form:"cursor"`
+	Limit         int        `binding:"omitempty,min=1,max=100" form:"limit"`
+}
+
+type AuditFilter struct {
+	PullRequestID string
+	UserID        string
+	TeamName      string
+	Actor         string
+	Action        string
+	From          *time.Time
+	To            *time.Time
+	AfterTime     *time.Time
+	AfterID       int64
+	Limit         int
+}
+
+type AuditListResponse struct {
+	Events     []AuditEvent `json:"events"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
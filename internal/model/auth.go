@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+)
+
+type APIToken struct {
+	ID         int64      `json:"id"`
+	UserID     string     `json:"user_id"`
+	Name       string     `json:"name,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+type CreateAPITokenRequest struct {
+	UserID string `binding:"required" json:"user_id"`
+	Name   string `json:"name"`
+}
+
+type CreateAPITokenResponse struct {
+	Token    string   `json:"token"`
+	APIToken APIToken `json:"api_token"`
+}
+
+type APITokenIDRequest struct {
+	ID int64 `binding:"required" json:"id"`
+}
+
+type APITokenQueryParam struct {
+	UserID string `form:"user_id"`
+}
+
+type APITokenListResponse struct {
+	Tokens []APIToken `json:"tokens"`
+}
+
+type SetUserRoleRequest struct {
+	UserID string `binding:"required"                              json:"user_id"`
+	Role   string `binding:"required,oneof=admin team-lead member" json:"role"`
+}
+
+type UserRoleResponse struct {
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+type ActorResponse struct {
+	UserID string   `json:"user_id"`
+	Role   string   `json:"role"`
+	Teams  []string `json:"teams"`
+}
@@ -0,0 +1,48 @@
+package model
+
+import (
+	"time"
+)
+
+type CodeOwnerRule struct {
+	ID        int64     `json:"id"`
+	TeamID    int       `json:"team_id"`
+	Pattern   string    `json:"pattern"`
+	Users     []string  `json:"users"`
+	Teams     []string  `json:"teams"`
+	Required  bool      `json:"required"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CodeOwnerMatch struct {
+	Rule  CodeOwnerRule `json:"rule"`
+	Paths []string      `json:"paths"`
+}
+
+type AddCodeOwnerRuleRequest struct {
+	TeamName string   `binding:"required"               json:"team_name"`
+	Pattern  string   `binding:"required"               json:"pattern"`
+	Users    []string `binding:"required_without=Teams" json:"users"`
+	Teams    []string `binding:"required_without=Users" json:"teams"`
+	Required bool     `json:"required"`
+}
+
+type CodeOwnerRuleIDRequest struct {
+	ID int64 `binding:"required" json:"id"`
+}
+
+type CodeOwnerMatchRequest struct {
+	TeamName string   `binding:"required"       json:"team_name"`
+	Paths    []string `binding:"required,min=1" json:"paths"`
+}
+
+type CodeOwnerRulesResponse struct {
+	TeamName string          `json:"team_name"`
+	Rules    []CodeOwnerRule `json:"rules"`
+}
+
+type CodeOwnerMatchResponse struct {
+	TeamName     string           `json:"team_name"`
+	Matches      []CodeOwnerMatch `json:"matches"`
+	UnownedPaths []string         `json:"unowned_paths"`
+}
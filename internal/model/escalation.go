@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	StaleReviewReassign    = "REASSIGN"
+	StaleReviewAddReviewer = "ADD_REVIEWER"
+)
+
+type StaleReview struct {
+	PullRequestID string
+	TeamID        int
+	TeamName      string
+	ReviewerID    string
+	AssignedAt    time.Time
+	Action        string
+}
+
+type ReviewEscalation struct {
+	PullRequestID string     `json:"pull_request_id"`
+	TeamName      string     `json:"team_name"`
+	ReviewerID    string     `json:"reviewer_id"`
+	AssignedAt    time.Time  `json:"assigned_at"`
+	Action        string     `json:"action"`
+	NewReviewerID string     `json:"new_reviewer_id,omitempty"`
+	CreatedAt     *time.Time `json:"created_at,omitempty"`
+}
+
+type EscalationStats struct {
+	TeamName        string     `json:"team_name"`
+	Reassigned      int        `json:"reassigned"`
+	ReviewersAdded  int        `json:"reviewers_added"`
+	NoCandidate     int        `json:"no_candidate"`
+	LastEscalatedAt *time.Time `json:"last_escalated_at,omitempty"`
+}
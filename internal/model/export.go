@@ -0,0 +1,78 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	ExportReportReviewers       = "reviewers"
+	ExportReportPullRequests    = "pull_requests"
+	ExportReportEscalations     = "escalations"
+	ExportReportMergeTimeTeam   = "merge_time_team"
+	ExportReportMergeTimeAuthor = "merge_time_author"
+	ExportReportOpenLoad        = "open_load"
+	ExportReportReassignments   = "reassignments"
+	ExportReportMergedPerWeek   = "merged_per_week"
+	ExportReportFairness        = "fairness"
+)
+
+type PullRequestExportQueryParam struct {
+	Format      string     `binding:"omitempty,oneof=csv ndjson"                          form:"format"`
+	AuthorID    string     `form:"author_id"`
+	ReviewerID  string     `form:"reviewer_id"`
+	TeamName    string     `form:"team_name"`
+	Status      string     `binding:"omitempty,oneof=DRAFT OPEN MERGED CLOSED REOPENED" form:"status"`
+	CreatedFrom *time.Time `form:"created_from"                                          time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   *time.Time `form:"created_to"                                            time_format:"2006-01-02T15:04:05Z07:00"`
+	MergedFrom  *time.Time `form:"merged_from"                                           time_format:"2006-01-02T15:04:05Z07:00"`
+	MergedTo    *time.Time `form:"merged_to"                                             time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type AssignmentExportQueryParam struct {
+	Format        string     `binding:"omitempty,oneof=csv ndjson" form:"format"`
+	PullRequestID string     `form:"pull_request_id"`
+	UserID        string     `form:"user_id"`
+	TeamName      string     `form:"team_name"`
+	From          *time.Time `form:"from"                          time_format:"2006-01-02T15:04:05Z07:00"`
+	To            *time.Time `form:"to"                            time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type StatsExportQueryParam struct {
+	Format   string     `binding:"omitempty,oneof=csv ndjson" form:"format"`
+	Report   string     `binding:"required"                   form:"report"`
+	TeamName string     `form:"team_name"`
+	From     *time.Time `form:"from"                          time_format:"2006-01-02T15:04:05Z07:00"`
+	To       *time.Time `form:"to"                            time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+type PullRequestExportRow struct {
+	PullRequestID   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+	AuthorID        string     `json:"author_id"`
+	Status          string     `json:"status"`
+	TeamName        string     `json:"team_name,omitempty"`
+	Reviewers       []string   `json:"assigned_reviewers"`
+	CreatedAt       *time.Time `json:"created_at,omitempty"`
+	MergedAt        *time.Time `json:"merged_at,omitempty"`
+	MergedBy        *string    `json:"merged_by,omitempty"`
+}
+
+type AssignmentExportRow struct {
+	ID              int64     `json:"id"`
+	CreatedAt       time.Time `json:"created_at"`
+	Actor           string    `json:"actor"`
+	Action          string    `json:"action"`
+	PullRequestID   string    `json:"pull_request_id,omitempty"`
+	UserID          string    `json:"user_id,omitempty"`
+	TeamName        string    `json:"team_name,omitempty"`
+	Reason          string    `json:"reason,omitempty"`
+	ReplacedBy      []string  `json:"replaced_by,omitempty"`
+	ReviewersBefore []string  `json:"reviewers_before"`
+	ReviewersAfter  []string  `json:"reviewers_after"`
+}
+
+type FairnessExportRow struct {
+	TeamName  string  `json:"team_name"`
+	GiniIndex float64 `json:"gini_index"`
+	MemberFairness
+}
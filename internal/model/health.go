@@ -0,0 +1,48 @@
+package model
+
+const (
+	HealthStatusOK       = "ok"
+	HealthStatusFail     = "fail"
+	HealthStatusDraining = "draining"
+)
+
+type HealthResponse struct {
+	Status string `json:"status"`
+}
+
+type PoolStats struct {
+	AcquiredConns int32 `json:"acquired_conns"`
+	IdleConns     int32 `json:"idle_conns"`
+	TotalConns    int32 `json:"total_conns"`
+	MaxConns      int32 `json:"max_conns"`
+}
+
+type HealthCheck struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+type MigrationCheck struct {
+	HealthCheck
+
+	Version  uint64 `json:"version"`
+	Expected uint64 `json:"expected"`
+	Dirty    bool   `json:"dirty"`
+}
+
+type PoolCheck struct {
+	PoolStats
+
+	Saturation float64 `json:"saturation"`
+}
+
+type ReadinessChecks struct {
+	Database   HealthCheck    `json:"database"`
+	Migrations MigrationCheck `json:"migrations"`
+	Pool       PoolCheck      `json:"pool"`
+}
+
+type ReadinessResponse struct {
+	Status string           `json:"status"`
+	Checks *ReadinessChecks `json:"checks,omitempty"`
+}
@@ -0,0 +1,15 @@
+package model
+
+import (
+	"time"
+)
+
+type IdempotencyRecord struct {
+	Key          string
+	Route        string
+	ActorID      string
+	RequestHash  string
+	StatusCode   *int
+	ResponseBody []byte
+	ExpiresAt    time.Time
+}
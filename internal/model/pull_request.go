@@ -9,23 +9,35 @@ type PullRequest struct {
 	PullRequestName string     `json:"pull_request_name"`
 	AuthorID        string     `json:"author_id"`
 	Status          string     `json:"status"`
+	TeamID          *int       `json:"team_id,omitempty"`
 	Assigned        []string   `json:"assigned_reviewers"`
+	Reviews         []Review   `json:"reviews,omitempty"`
+	ChangedPaths    []string   `json:"changed_paths,omitempty"`
 	CreatedAt       *time.Time `json:"createdAt,omitempty"`
 	MergedAt        *time.Time `json:"mergedAt,omitempty"`
+	MergedBy        *string    `json:"merged_by,omitempty"`
 }
 
 type PullRequestWithAssignedReviewers struct {
-	PullRequestID   string   `json:"pull_request_id"`
-	PullRequestName string   `json:"pull_request_name"`
-	AuthorID        string   `json:"author_id"`
-	Status          string   `json:"status"`
-	Assigned        []string `json:"assigned_reviewers"`
+	PullRequestID    string   `json:"pull_request_id"`
+	PullRequestName  string   `json:"pull_request_name"`
+	AuthorID         string   `json:"author_id"`
+	Status           string   `json:"status"`
+	TeamID           *int     `json:"team_id,omitempty"`
+	Assigned         []string `json:"assigned_reviewers"`
+	Reviews          []Review `json:"reviews,omitempty"`
+	OwnerReviewers   []string `json:"owner_reviewers,omitempty"`
+	PoolReviewers    []string `json:"pool_reviewers,omitempty"`
+	MissingReviewers int      `json:"missing_reviewers,omitempty"`
 }
 
 type PullRequestCreateRequest struct {
-	PullRequestID   string `json:"pull_request_id"`
-	PullRequestName string `json:"pull_request_name"`
-	AuthorID        string `json:"author_id"`
+	PullRequestID   string   `json:"pull_request_id"`
+	PullRequestName string   `json:"pull_request_name"`
+	AuthorID        string   `json:"author_id"`
+	TeamID          *int     `json:"team_id"`
+	Draft           bool     `json:"draft"`
+	ChangedPaths    []string `json:"changed_paths"`
 }
 
 type PullRequestResponse struct {
@@ -38,6 +50,7 @@ type PullRequestResponse struct {
 type GetReviewResponse struct {
 	UserID       string                `json:"user_id"`
 	PullRequests []PullRequestResponse `json:"pull_requests"`
+	Load         ReviewLoad            `json:"load"`
 }
 
 type GetReviewRequestUserIDParam struct {
@@ -48,18 +61,91 @@ type MergedResponse struct {
 	PullRequestWithAssignedReviewers
 
 	MergedAt time.Time `json:"mergedAt"`
+	MergedBy *string   `json:"merged_by,omitempty"`
 }
 
 type MergedRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 }
 
+type PullRequestStatusRequest struct {
+	PullRequestID string `binding:"required" json:"pull_request_id"`
+}
+
 type ReassignRequest struct {
 	PullRequestID string `json:"pull_request_id"`
 	OldReviewerID string `json:"old_reviewer_id"`
 }
 
 type ReassignResponse struct {
-	PR         PullRequestWithAssignedReviewers `json:"pr"`
-	ReplacedBy string                           `json:"replaced_by"`
+	PR           PullRequestWithAssignedReviewers `json:"pr"`
+	ReplacedBy   string                           `json:"replaced_by"`
+	NewReviewers []string                         `json:"new_reviewers"`
+}
+
+type ReviewReassignment struct {
+	PullRequestID string   `json:"pull_request_id"`
+	OldReviewerID string   `json:"old_reviewer_id"`
+	ReplacedBy    string   `json:"replaced_by,omitempty"`
+	NewReviewers  []string `json:"new_reviewers,omitempty"`
+}
+
+type ReviewReassignments struct {
+	Reassigned []ReviewReassignment `json:"reassigned"`
+	Uncovered  []ReviewReassignment `json:"uncovered"`
+}
+
+type ReviewerCandidate struct {
+	UserID      string
+	OpenReviews int
+}
+
+type Review struct {
+	ReviewerID string    `json:"reviewer_id"`
+	Verdict    string    `json:"verdict"`
+	Comment    string    `json:"comment,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type ReviewRequest struct {
+	PullRequestID string `binding:"required"                                            json:"pull_request_id"`
+	ReviewerID    string `binding:"required"                                            json:"reviewer_id"`
+	Verdict       string `binding:"required,oneof=APPROVED CHANGES_REQUESTED COMMENTED" json:"verdict"`
+	Comment       string `json:"comment"`
+}
+
+type PullRequestIDQueryParam struct {
+	PullRequestID string `binding:"required" form:"pull_request_id"`
+}
+
+type PullRequestListQueryParam struct {
+	AuthorID    string     `form:"author_id"`
+	ReviewerID  string     `form:"reviewer_id"`
+	TeamName    string     `form:"team_name"`
+	Status      string     `binding:"omitempty,oneof=DRAFT OPEN MERGED CLOSED REOPENED" form:"status"`
+	CreatedFrom *time.Time `form:"created_from" time_format:"2006-01-02T15:04:05Z07:00"`
+	CreatedTo   *time.Time `form:"created_to"   time_format:"2006-01-02T15:04:05Z07:00"`
+	MergedFrom  *time.Time `form:"merged_from"  time_format:"2006-01-02T15:04:05Z07:00"`
+	MergedTo    *time.Time `form:"merged_to"    time_format:"2006-01-02T15:04:05Z07:00"`
+	Cursor      string     `form:"cursor"`
+	Limit       int        `binding:"omitempty,min=1,max=100"                           form:"limit"`
+}
+
+type PullRequestFilter struct {
+	AuthorID    string
+	ReviewerID  string
+	TeamName    string
+	Status      string
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	MergedFrom  *time.Time
+	MergedTo    *time.Time
+	AfterTime   *time.Time
+	AfterID     string
+	Limit       int
+}
+
+type PullRequestListResponse struct {
+	PullRequests []*PullRequest `json:"pull_requests"`
+	NextCursor   string         `json:"next_cursor,omitempty"`
 }
@@ -0,0 +1,63 @@
+package model
+
+import (
+	"time"
+)
+
+const (
+	PoolModeAlways   = "ALWAYS"
+	PoolModeFallback = "FALLBACK"
+)
+
+type ReviewerPool struct {
+	ID        int            `json:"pool_id"`
+	Name      string         `json:"pool_name"`
+	Members   []string       `json:"members"`
+	Teams     []PoolTeamLink `json:"teams"`
+	CreatedAt time.Time      `json:"created_at"`
+}
+
+type PoolTeamLink struct {
+	TeamName string `json:"team_name"`
+	Mode     string `json:"mode"`
+}
+
+type TeamReviewerPool struct {
+	PoolID   int
+	PoolName string
+	Mode     string
+	Members  []string
+}
+
+type CreateReviewerPoolRequest struct {
+	PoolName string   `binding:"required" json:"pool_name"`
+	Members  []string `json:"members"`
+}
+
+type ReviewerPoolNameRequest struct {
+	PoolName string `binding:"required" json:"pool_name"`
+}
+
+type ReviewerPoolNameQueryParam struct {
+	PoolName string `binding:"required" form:"pool_name"`
+}
+
+type ReviewerPoolMembersRequest struct {
+	PoolName string   `binding:"required"       json:"pool_name"`
+	UserIDs  []string `binding:"required,min=1" json:"user_ids"`
+}
+
+type AttachReviewerPoolRequest struct {
+	TeamName string `binding:"required"                      json:"team_name"`
+	PoolName string `binding:"required"                      json:"pool_name"`
+	Mode     string `binding:"required,oneof=ALWAYS FALLBACK" json:"mode"`
+}
+
+type DetachReviewerPoolRequest struct {
+	TeamName string `binding:"required" json:"team_name"`
+	PoolName string `binding:"required" json:"pool_name"`
+}
+
+type ReviewerPoolListResponse struct {
+	Pools []ReviewerPool `json:"pools"`
+}
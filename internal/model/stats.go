@@ -1,5 +1,9 @@
 package model
 
+import (
+	"time"
+)
+
 type ReviewerStats struct {
 	ReviewerID    string `db:"reviewer_id"    json:"reviewer_id"`
 	AssignedCount int    `db:"assigned_count" json:"assigned_count"`
@@ -11,6 +15,94 @@ type PRStats struct {
 }
 
 type StatsResponse struct {
-	ReviewerStats []ReviewerStats `json:"reviewer_stats"`
-	PRStats       []PRStats       `json:"pr_stats"`
+	ReviewerStats []ReviewerStats   `json:"reviewer_stats"`
+	PRStats       []PRStats         `json:"pr_stats"`
+	Escalations   []EscalationStats `json:"escalations"`
+}
+
+type StatsQueryParam struct {
+	TeamName string     `form:"team_name"`
+	From     *time.Time `form:"from"      time_format:"2006-01-02T15:04:05Z07:00"`
+	To       *time.Time `form:"to"        time_format:"2006-01-02T15:04:05Z07:00"`
+}
+
+// StatsFilter bounds analytics to [From, To) and to one team. Nil and empty values are unbounded.
+type StatsFilter struct {
+	TeamName string
+	From     *time.Time
+	To       *time.Time
+}
+
+type MergeTimeStats struct {
+	TeamName      string  `json:"team_name,omitempty"`
+	AuthorID      string  `json:"author_id,omitempty"`
+	Merged        int     `json:"merged"`
+	MedianSeconds float64 `json:"median_seconds"`
+	P90Seconds    float64 `json:"p90_seconds"`
+}
+
+type OpenLoadStats struct {
+	ReviewerID  string `json:"reviewer_id"`
+	OpenReviews int    `json:"open_reviews"`
+}
+
+type ReassignmentStats struct {
+	TeamName string `json:"team_name"`
+	Reason   string `json:"reason"`
+	Count    int    `json:"count"`
+}
+
+type MergedPerWeekStats struct {
+	WeekStart time.Time `json:"week_start"`
+	Merged    int       `json:"merged"`
+}
+
+type AnalyticsResponse struct {
+	TeamName          string               `json:"team_name,omitempty"`
+	From              *time.Time           `json:"from,omitempty"`
+	To                *time.Time           `json:"to,omitempty"`
+	MergeTimeByTeam   []MergeTimeStats     `json:"merge_time_by_team"`
+	MergeTimeByAuthor []MergeTimeStats     `json:"merge_time_by_author"`
+	OpenLoad          []OpenLoadStats      `json:"open_load"`
+	Reassignments     []ReassignmentStats  `json:"reassignments"`
+	MergedPerWeek     []MergedPerWeekStats `json:"merged_per_week"`
+}
+
+const (
+	FairShareOver  = "OVER"
+	FairShareUnder = "UNDER"
+	FairShareFair  = "FAIR"
+)
+
+type MemberAssignments struct {
+	TeamName    string
+	UserID      string
+	Username    string
+	IsActive    bool
+	ActiveFrom  time.Time
+	Assignments int
+}
+
+type MemberFairness struct {
+	UserID        string  `json:"user_id"`
+	Username      string  `json:"username"`
+	IsActive      bool    `json:"is_active"`
+	ActiveDays    float64 `json:"active_days"`
+	Assignments   int     `json:"assignments"`
+	Share         float64 `json:"share"`
+	ExpectedShare float64 `json:"expected_share"`
+	Status        string  `json:"status"`
+}
+
+type TeamFairness struct {
+	TeamName    string           `json:"team_name"`
+	Assignments int              `json:"assignments"`
+	GiniIndex   float64          `json:"gini_index"`
+	Members     []MemberFairness `json:"members"`
+}
+
+type FairnessResponse struct {
+	From  time.Time      `json:"from"`
+	To    time.Time      `json:"to"`
+	Teams []TeamFairness `json:"teams"`
 }
@@ -1,8 +1,24 @@
 package model
 
+type Team struct {
+	ID                    int
+	Name                  string
+	ReviewersCount        int
+	RequiredApprovals     int
+	DefaultMaxOpenReviews *int
+	ReviewSLAMinutes      *int
+	StaleReviewAction     string
+}
+
 type TeamResponse struct {
-	TeamName string         `json:"team_name"`
-	Members  []UserResponse `json:"members"`
+	TeamID                int            `json:"team_id"`
+	TeamName              string         `json:"team_name"`
+	ReviewersCount        int            `json:"reviewers_count"`
+	RequiredApprovals     int            `json:"required_approvals"`
+	DefaultMaxOpenReviews *int           `json:"default_max_open_reviews,omitempty"`
+	ReviewSLAMinutes      *int           `json:"review_sla_minutes,omitempty"`
+	StaleReviewAction     string         `json:"stale_review_action,omitempty"`
+	Members               []UserResponse `json:"members"`
 }
 
 type AddTeamRequest struct {
@@ -13,3 +29,53 @@ type AddTeamRequest struct {
 type TeamNameQueryParam struct {
 	TeamName string `binding:"required" form:"team_name"`
 }
+
+type SetReviewersCountRequest struct {
+	TeamName       string `binding:"required"              json:"team_name"`
+	ReviewersCount int    `binding:"required,min=1,max=10" json:"reviewers_count"`
+}
+
+type SetRequiredApprovalsRequest struct {
+	TeamName          string `binding:"required"              json:"team_name"`
+	RequiredApprovals *int   `binding:"required,min=0,max=10" json:"required_approvals"`
+}
+
+type SetTeamMaxOpenReviewsRequest struct {
+	TeamName       string `binding:"required"        json:"team_name"`
+	MaxOpenReviews *int   `binding:"omitempty,min=0" json:"max_open_reviews"`
+}
+
+type SetReviewSLARequest struct {
+	TeamName          string `binding:"required"                                json:"team_name"`
+	ReviewSLAMinutes  *int   `binding:"omitempty,min=1"                         json:"review_sla_minutes"`
+	StaleReviewAction string `binding:"omitempty,oneof=REASSIGN ADD_REVIEWER" json:"stale_review_action"`
+}
+
+type TeamMembersRequest struct {
+	TeamName string        `binding:"required"       json:"team_name"`
+	Members  []UserRequest `binding:"required,min=1" json:"members"`
+}
+
+type RemoveTeamMembersRequest struct {
+	TeamName string   `binding:"required"       json:"team_name"`
+	UserIDs  []string `binding:"required,min=1" json:"user_ids"`
+}
+
+type MoveTeamMemberRequest struct {
+	UserID   string `binding:"required"                 json:"user_id"`
+	FromTeam string `binding:"required"                 json:"from_team"`
+	ToTeam   string `binding:"required,nefield=FromTeam" json:"to_team"`
+}
+
+type TeamMembersResponse struct {
+	Team       *TeamResponse        `json:"team"`
+	Reassigned []ReviewReassignment `json:"reassigned"`
+	Uncovered  []ReviewReassignment `json:"uncovered"`
+}
+
+type MoveTeamMemberResponse struct {
+	FromTeam   *TeamResponse        `json:"from_team"`
+	ToTeam     *TeamResponse        `json:"to_team"`
+	Reassigned []ReviewReassignment `json:"reassigned"`
+	Uncovered  []ReviewReassignment `json:"uncovered"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+)
+
+type Unavailability struct {
+	ID           int64      `json:"id"`
+	UserID       string     `json:"user_id"`
+	StartsAt     time.Time  `json:"starts_at"`
+	EndsAt       time.Time  `json:"ends_at"`
+	Reason       string     `json:"reason,omitempty"`
+	ReassignedAt *time.Time `json:"reassigned_at,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+type CreateUnavailabilityRequest struct {
+	UserID   string    `binding:"required"                  json:"user_id"`
+	StartsAt time.Time `binding:"required"                  json:"starts_at"`
+	EndsAt   time.Time `binding:"required,gtfield=StartsAt" json:"ends_at"`
+	Reason   string    `json:"reason"`
+}
+
+type CreateUnavailabilityResponse struct {
+	Unavailability Unavailability       `json:"unavailability"`
+	Reassigned     []ReviewReassignment `json:"reassigned,omitempty"`
+	Uncovered      []ReviewReassignment `json:"uncovered,omitempty"`
+}
+
+type UnavailabilityIDRequest struct {
+	ID int64 `binding:"required" json:"id"`
+}
+
+type UnavailabilityQueryParam struct {
+	UserID      string `form:"user_id"`
+	IncludePast bool   `form:"include_past"`
+}
+
+type UnavailabilityListResponse struct {
+	Unavailability []Unavailability `json:"unavailability"`
+}
@@ -11,27 +11,63 @@ type UserRequest struct {
 }
 
 type User struct {
-	ID        string
-	Username  string
-	IsActive  bool
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID             string
+	Username       string
+	IsActive       bool
+	MaxOpenReviews *int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
 }
 
 type UserResponse struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+	UserID         string `json:"user_id"`
+	Username       string `json:"username"`
+	IsActive       bool   `json:"is_active"`
+	MaxOpenReviews *int   `json:"max_open_reviews,omitempty"`
 }
 
-type UserResponseWithTeamName struct {
+type UserTeam struct {
+	TeamID   int    `json:"team_id"`
 	TeamName string `json:"team_name"`
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
+}
+
+type UserResponseWithTeamName struct {
+	TeamName string     `json:"team_name"`
+	Teams    []UserTeam `json:"teams"`
+	UserID   string     `json:"user_id"`
+	Username string     `json:"username"`
+	IsActive bool       `json:"is_active"`
 }
 
 type UserIsActiveRequest struct {
 	UserID   string `binding:"required" json:"user_id"`
 	IsActive bool   `json:"is_active"`
 }
+
+type BulkDeactivateRequest struct {
+	TeamName string   `binding:"required_without=UserIDs" json:"team_name"`
+	UserIDs  []string `binding:"required_without=TeamName" json:"user_ids"`
+}
+
+type BulkDeactivateResponse struct {
+	Deactivated []string             `json:"deactivated"`
+	Reassigned  []ReviewReassignment `json:"reassigned"`
+	Uncovered   []ReviewReassignment `json:"uncovered"`
+}
+
+type SetMaxOpenReviewsRequest struct {
+	UserID         string `binding:"required"        json:"user_id"`
+	MaxOpenReviews *int   `binding:"omitempty,min=0" json:"max_open_reviews"`
+}
+
+type ReviewLoad struct {
+	OpenReviews    int  `json:"open_reviews"`
+	MaxOpenReviews *int `json:"max_open_reviews"`
+	AtCapacity     bool `json:"at_capacity"`
+}
+
+type ReviewLoadResponse struct {
+	ReviewLoad
+
+	UserID string `json:"user_id"`
+}
@@ -0,0 +1,101 @@
+package model
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	WebhookEventReviewersAssigned  = "reviewers.assigned"
+	WebhookEventReviewerReassigned = "reviewer.reassigned"
+	WebhookEventReviewerRemoved    = "reviewer.removed"
+	WebhookEventPullRequestMerged  = "pull_request.merged"
+
+	WebhookDeliveryPending   = "PENDING"
+	WebhookDeliveryDelivered = "DELIVERED"
+	WebhookDeliveryDead      = "DEAD"
+)
+
+type WebhookSubscription struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	Secret    string    `json:"secret,omitempty"`
+	Events    []string  `json:"events"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateWebhookRequest struct {
+	URL    string   `binding:"required,url"                                                                                   json:"url"`
+	Secret string   `json:"secret"`
+	Events []string `binding:"omitempty,dive,oneof=reviewers.assigned reviewer.reassigned reviewer.removed pull_request.merged" json:"events"`
+}
+
+type WebhookIDRequest struct {
+	ID int64 `binding:"required" json:"id"`
+}
+
+type WebhookListResponse struct {
+	Webhooks []WebhookSubscription `json:"webhooks"`
+}
+
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	SubscriptionID int64           `json:"subscription_id"`
+	EventID        int64           `json:"event_id"`
+	EventType      string          `json:"event_type"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"`
+	Attempts       int             `json:"attempts"`
+	LastStatusCode *int            `json:"last_status_code,omitempty"`
+	LastError      string          `json:"last_error,omitempty"`
+	EventCreatedAt time.Time       `json:"event_created_at"`
+	CreatedAt      time.Time       `json:"created_at"`
+
+	URL    string `json:"-"`
+	Secret string `json:"-"`
+}
+
+type WebhookDeliveryResult struct {
+	ID            int64
+	Status        string
+	Attempts      int
+	StatusCode    *int
+	Error         string
+	NextAttemptAt time.Time
+}
+
+type DeadLettersQueryParam struct {
+	SubscriptionID int64 `form:"subscription_id"`
+	Limit          int   `binding:"omitempty,min=1,max=100" form:"limit"`
+}
+
+type DeadLettersResponse struct {
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+type WebhookEnvelope struct {
+	EventID    int64           `json:"event_id"`
+	Event      string          `json:"event"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Data       json.RawMessage `json:"data"`
+}
+
+type PullRequestEventData struct {
+	PullRequestID   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+	AuthorID        string     `json:"author_id"`
+	Status          string     `json:"status"`
+	TeamID          *int       `json:"team_id,omitempty"`
+	Reviewers       []string   `json:"reviewers"`
+	MergedAt        *time.Time `json:"merged_at,omitempty"`
+	MergedBy        *string    `json:"merged_by,omitempty"`
+}
+
+type ReviewerEventData struct {
+	PullRequestID string   `json:"pull_request_id"`
+	ReviewerID    string   `json:"reviewer_id"`
+	ReplacedBy    []string `json:"replaced_by,omitempty"`
+	Reviewers     []string `json:"reviewers"`
+	Reason        string   `json:"reason"`
+}
@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"avito-test-assignment/internal/model"
+)
+
+type AnalyticsRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAnalyticsRepository(db *pgxpool.Pool) *AnalyticsRepository {
+	return &AnalyticsRepository{db: db}
+}
+
+func (r *AnalyticsRepository) Pool() *pgxpool.Pool {
+	return r.db
+}
+
+func (r *AnalyticsRepository) GetMergeTimeStats(
+	ctx context.Context,
+	ext RepoExtension,
+	filter model.StatsFilter,
+	byAuthor bool,
+) ([]model.MergeTimeStats, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT COALESCE(t.team_name, '') AS team_name,
+		       CASE WHEN $4 THEN pr.author_id ELSE '' END AS author_id,
+		       COUNT(*) AS merged,
+		       percentile_cont(0.5) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM pr.merged_at - pr.created_at)) AS median_seconds,
+		       percentile_cont(0.9) WITHIN GROUP (ORDER BY EXTRACT(EPOCH FROM pr.merged_at - pr.created_at)) AS p90_seconds
+		FROM pull_requests pr
+		LEFT JOIN teams t ON t.id = pr.team_id
+		WHERE pr.status = 'MERGED'
+		  AND pr.merged_at IS NOT NULL
+		  AND pr.created_at IS NOT NULL
+		  AND ($1 = '' OR t.team_name = $1)
+		  AND ($2::timestamptz IS NULL OR pr.merged_at >= $2)
+		  AND ($3::timestamptz IS NULL OR pr.merged_at < $3)
+		GROUP BY 1, 2
+		ORDER BY 1, 2;
+	`
+
+	rows, err := ext.Query(ctx, query, filter.TeamName, filter.From, filter.To, byAuthor)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	stats := make([]model.MergeTimeStats, 0, listDefaultCap)
+
+	for rows.Next() {
+		var s model.MergeTimeStats
+
+		if err := rows.Scan(&s.TeamName, &s.AuthorID, &s.Merged, &s.MedianSeconds, &s.P90Seconds); err != nil {
+			return nil, err
+		}
+
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
+func (r *AnalyticsRepository) GetOpenLoadStats(ctx context.Context, ext RepoExtension, teamName string) ([]model.OpenLoadStats, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT prr.reviewer_id, COUNT(*) AS open_reviews
+		FROM pr_reviewers prr
+		JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		LEFT JOIN teams t ON t.id = pr.team_id
+		WHERE pr.status IN ('OPEN', 'REOPENED')
+		  AND ($1 = '' OR t.team_name = $1)
+		GROUP BY prr.reviewer_id
+		ORDER BY open_reviews DESC, prr.reviewer_id;
+	`
+
+	rows, err := ext.Query(ctx, query, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	stats := make([]model.OpenLoadStats, 0, listDefaultCap)
+
+	for rows.Next() {
+		var s model.OpenLoadStats
+
+		if err := rows.Scan(&s.ReviewerID, &s.OpenReviews); err != nil {
+			return nil, err
+		}
+
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
+func (r *AnalyticsRepository) GetReassignmentStats(
+	ctx context.Context,
+	ext RepoExtension,
+	filter model.StatsFilter,
+) ([]model.ReassignmentStats, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT COALESCE(a.team_name, '') AS team_name,
+		       COALESCE(a.after->>'reason', '') AS reason,
+		       COUNT(*) AS reassignments
+		FROM audit_events a
+		WHERE a.action = 'REVIEWER_REASSIGNED'
+		  AND ($1 = '' OR a.team_name = $1)
+		  AND ($2::timestamptz IS NULL OR a.created_at >= $2)
+		  AND ($3::timestamptz IS NULL OR a.created_at < $3)
+		GROUP BY 1, 2
+		ORDER BY 1, 2;
+	`
+
+	rows, err := ext.Query(ctx, query, filter.TeamName, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	stats := make([]model.ReassignmentStats, 0, listDefaultCap)
+
+	for rows.Next() {
+		var s model.ReassignmentStats
+
+		if err := rows.Scan(&s.TeamName, &s.Reason, &s.Count); err != nil {
+			return nil, err
+		}
+
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
+func (r *AnalyticsRepository) GetMergedPerWeekStats(
+	ctx context.Context,
+	ext RepoExtension,
+	filter model.StatsFilter,
+) ([]model.MergedPerWeekStats, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT date_trunc('week', pr.merged_at) AS week_start, COUNT(*) AS merged
+		FROM pull_requests pr
+		LEFT JOIN teams t ON t.id = pr.team_id
+		WHERE pr.status = 'MERGED'
+		  AND pr.merged_at IS NOT NULL
+		  AND ($1 = '' OR t.team_name = $1)
+		  AND ($2::timestamptz IS NULL OR pr.merged_at >= $2)
+		  AND ($3::timestamptz IS NULL OR pr.merged_at < $3)
+		GROUP BY 1
+		ORDER BY 1;
+	`
+
+	rows, err := ext.Query(ctx, query, filter.TeamName, filter.From, filter.To)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	stats := make([]model.MergedPerWeekStats, 0, listDefaultCap)
+
+	for rows.Next() {
+		var s model.MergedPerWeekStats
+
+		if err := rows.Scan(&s.WeekStart, &s.Merged); err != nil {
+			return nil, err
+		}
+
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
+
+// GetMemberAssignments skips inactive members without assignments.
+func (r *AnalyticsRepository) GetMemberAssignments(
+	ctx context.Context,
+	ext RepoExtension,
+	teamName string,
+	from, to time.Time,
+) ([]model.MemberAssignments, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT t.team_name,
+		       u.id,
+		       u.username,
+		       u.is_active,
+		       GREATEST($2, COALESCE(u.created_at, $2)) AS active_from,
+		       a.assignments
+		FROM teams t
+		JOIN team_lnk tl ON tl.team_id = t.id
+		JOIN users u ON u.id = tl.user_id
+		CROSS JOIN LATERAL (
+		    SELECT COUNT(*) AS assignments
+		    FROM pr_reviewers prr
+		    JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		    WHERE pr.team_id = t.id
+		      AND prr.reviewer_id = u.id
+		      AND prr.assigned_at >= $2
+		      AND prr.assigned_at < $3
+		) a
+		WHERE ($1 = '' OR t.team_name = $1)
+		  AND (u.is_active OR a.assignments > 0)
+		ORDER BY t.team_name, u.id;
+	`
+
+	rows, err := ext.Query(ctx, query, teamName, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	members := make([]model.MemberAssignments, 0, listDefaultCap)
+
+	for rows.Next() {
+		var m model.MemberAssignments
+
+		if err := rows.Scan(&m.TeamName, &m.UserID, &m.Username, &m.IsActive, &m.ActiveFrom, &m.Assignments); err != nil {
+			return nil, err
+		}
+
+		members = append(members, m)
+	}
+
+	return members, rows.Err()
+}
+
+func (r *AnalyticsRepository) GetUnavailabilityInRange(
+	ctx context.Context,
+	ext RepoExtension,
+	teamName string,
+	from, to time.Time,
+) ([]model.Unavailability, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT ua.id, ua.user_id, ua.starts_at, ua.ends_at, ua.reason, ua.reassigned_at, ua.created_at
+		FROM user_unavailability ua
+		WHERE ua.starts_at < $3
+		  AND ua.ends_at > $2
+		  AND EXISTS (
+		      SELECT 1
+		      FROM team_lnk tl
+		      JOIN teams t ON t.id = tl.team_id
+		      WHERE tl.user_id = ua.user_id
+		        AND ($1 = '' OR t.team_name = $1)
+		  )
+		ORDER BY ua.user_id, ua.starts_at;
+	`
+
+	rows, err := ext.Query(ctx, query, teamName, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	periods := make([]model.Unavailability, 0, listDefaultCap)
+
+	for rows.Next() {
+		var u model.Unavailability
+
+		if err := rows.Scan(&u.ID, &u.UserID, &u.StartsAt, &u.EndsAt, &u.Reason, &u.ReassignedAt, &u.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		periods = append(periods, u)
+	}
+
+	return periods, rows.Err()
+}
@@ -0,0 +1,226 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"avito-test-assignment/internal/model"
+)
+
+// testPool connects to TEST_DATABASE_URL and applies the migrations into a throwaway schema.
+func testPool(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	schema := fmt.Sprintf("test_%d", time.Now().UnixNano())
+
+	admin, err := pgxpool.New(ctx, dsn)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+
+	t.Cleanup(admin.Close)
+
+	if _, err := admin.Exec(ctx, "CREATE SCHEMA "+schema); err != nil {
+		t.Fatalf("create schema: %v", err)
+	}
+
+	t.Cleanup(func() {
+		_, _ = admin.Exec(context.Background(), "DROP SCHEMA "+schema+" CASCADE")
+	})
+
+	config, err := pgxpool.ParseConfig(dsn)
+	if err != nil {
+		t.Fatalf("parse dsn: %v", err)
+	}
+
+	config.ConnConfig.RuntimeParams["search_path"] = schema
+
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+
+	t.Cleanup(pool.Close)
+
+	files, err := filepath.Glob("../../migrations/*.up.sql")
+	if err != nil {
+		t.Fatalf("list migrations: %v", err)
+	}
+
+	sort.Strings(files)
+
+	for _, file := range files {
+		sql, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("read %s: %v", file, err)
+		}
+
+		if _, err := pool.Exec(ctx, string(sql)); err != nil {
+			t.Fatalf("apply %s: %v", file, err)
+		}
+	}
+
+	return pool
+}
+
+func mustExec(t *testing.T, pool *pgxpool.Pool, sql string, args ...any) {
+	t.Helper()
+
+	if _, err := pool.Exec(context.Background(), sql, args...); err != nil {
+		t.Fatalf("exec %q: %v", sql, err)
+	}
+}
+
+func TestAnalyticsMergeTimeStats(t *testing.T) {
+	pool := testPool(t)
+	repo := NewAnalyticsRepository(pool)
+	ctx := context.Background()
+
+	base := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	hours := func(h float64) time.Time { return base.Add(time.Duration(h * float64(time.Hour))) }
+
+	mustExec(t, pool, `INSERT INTO teams (id, team_name) VALUES (1, 'backend'), (2, 'frontend')`)
+	mustExec(t, pool, `INSERT INTO users (id, username) VALUES ('u1', 'Alice'), ('u2', 'Bob'), ('u3', 'Carol')`)
+
+	merged := []struct {
+		id        string
+		author    string
+		team      int
+		createdAt time.Time
+		mergedAt  time.Time
+	}{
+		{"pr-1", "u1", 1, base, hours(1)},
+		{"pr-2", "u1", 1, base, hours(2)},
+		{"pr-3", "u1", 1, base, hours(3)},
+		{"pr-4", "u2", 1, base, hours(4)},
+		{"pr-5", "u2", 1, base, hours(5)},
+		{"pr-old", "u2", 1, hours(-34), hours(-24)},
+		{"pr-fe", "u3", 2, base, hours(0.5)},
+	}
+
+	for _, pr := range merged {
+		mustExec(t, pool, `
+			INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, team_id, status, created_at, merged_at)
+			VALUES ($1, $1, $2, $3, 'MERGED', $4, $5)`,
+			pr.id, pr.author, pr.team, pr.createdAt, pr.mergedAt)
+	}
+
+	mustExec(t, pool, `
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, team_id, status, created_at)
+		VALUES ('pr-open', 'pr-open', 'u1', 1, 'OPEN', $1)`, base)
+
+	from, to, cutoff := base, hours(24), hours(3)
+	hour := time.Hour.Seconds()
+
+	tests := []struct {
+		name     string
+		filter   model.StatsFilter
+		byAuthor bool
+		want     []model.MergeTimeStats
+	}{
+		{
+			name:   "window by team",
+			filter: model.StatsFilter{From: &from, To: &to},
+			want: []model.MergeTimeStats{
+				{TeamName: "backend", Merged: 5, MedianSeconds: 3 * hour, P90Seconds: 4.6 * hour},
+				{TeamName: "frontend", Merged: 1, MedianSeconds: 0.5 * hour, P90Seconds: 0.5 * hour},
+			},
+		},
+		{
+			name:   "unbounded team",
+			filter: model.StatsFilter{TeamName: "backend"},
+			want: []model.MergeTimeStats{
+				{TeamName: "backend", Merged: 6, MedianSeconds: 3.5 * hour, P90Seconds: 7.5 * hour},
+			},
+		},
+		{
+			name:   "exclusive upper bound",
+			filter: model.StatsFilter{TeamName: "backend", From: &from, To: &cutoff},
+			want: []model.MergeTimeStats{
+				{TeamName: "backend", Merged: 2, MedianSeconds: 1.5 * hour, P90Seconds: 1.9 * hour},
+			},
+		},
+		{
+			name:     "window by author",
+			filter:   model.StatsFilter{TeamName: "backend", From: &from, To: &to},
+			byAuthor: true,
+			want: []model.MergeTimeStats{
+				{TeamName: "backend", AuthorID: "u1", Merged: 3, MedianSeconds: 2 * hour, P90Seconds: 2.8 * hour},
+				{TeamName: "backend", AuthorID: "u2", Merged: 2, MedianSeconds: 4.5 * hour, P90Seconds: 4.9 * hour},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := repo.GetMergeTimeStats(ctx, nil, tt.filter, tt.byAuthor)
+			if err != nil {
+				t.Fatalf("GetMergeTimeStats: %v", err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("GetMergeTimeStats = %+v, want %+v", got, tt.want)
+			}
+
+			for i := range got {
+				g, w := got[i], tt.want[i]
+				if g.TeamName != w.TeamName || g.AuthorID != w.AuthorID || g.Merged != w.Merged ||
+					math.Abs(g.MedianSeconds-w.MedianSeconds) > 1e-6 || math.Abs(g.P90Seconds-w.P90Seconds) > 1e-6 {
+					t.Errorf("row %d = %+v, want %+v", i, g, w)
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyticsReassignmentStats(t *testing.T) {
+	pool := testPool(t)
+	repo := NewAnalyticsRepository(pool)
+
+	base := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	for _, e := range []struct {
+		team   string
+		reason string
+		at     time.Time
+	}{
+		{"backend", "manual", base},
+		{"backend", "manual", base.Add(time.Hour)},
+		{"backend", "user_unavailable", base.Add(2 * time.Hour)},
+		{"backend", "manual", base.Add(-time.Hour)},
+		{"frontend", "manual", base.Add(time.Hour)},
+	} {
+		mustExec(t, pool, `
+			INSERT INTO audit_events (action, team_name, after, created_at)
+			VALUES ('REVIEWER_REASSIGNED', $1, jsonb_build_object('reason', $2::text), $3)`,
+			e.team, e.reason, e.at)
+	}
+
+	mustExec(t, pool, `INSERT INTO audit_events (action, team_name, created_at) VALUES ('PR_MERGED', 'backend', $1)`, base)
+
+	to := base.Add(2 * time.Hour)
+
+	got, err := repo.GetReassignmentStats(context.Background(), nil, model.StatsFilter{TeamName: "backend", From: &base, To: &to})
+	if err != nil {
+		t.Fatalf("GetReassignmentStats: %v", err)
+	}
+
+	want := []model.ReassignmentStats{{TeamName: "backend", Reason: "manual", Count: 2}}
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Fatalf("GetReassignmentStats = %+v, want %+v", got, want)
+	}
+}
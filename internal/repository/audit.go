@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"avito-test-assignment/internal/model"
+)
+
+type AuditRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditRepository(db *pgxpool.Pool) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+func (r *AuditRepository) Pool() *pgxpool.Pool {
+	return r.db
+}
+
+func (r *AuditRepository) InsertAuditEvent(ctx context.Context, ext RepoExtension, event *model.AuditEvent) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		INSERT INTO audit_events (actor, action, pull_request_id, user_id, team_name, before, after)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), $6, $7);
+	`
+
+	_, err := ext.Exec(ctx, query,
+		event.Actor,
+		event.Action,
+		event.PullRequestID,
+		event.UserID,
+		event.TeamName,
+		nullableJSON(event.Before),
+		nullableJSON(event.After),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *AuditRepository) SelectAuditEvents(ctx context.Context, ext RepoExtension, filter *model.AuditFilter) ([]model.AuditEvent, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT id,
+		       actor,
+		       action,
+		       COALESCE(pull_request_id, ''),
+		       COALESCE(user_id, ''),
+		       COALESCE(team_name, ''),
+		       before,
+		       after,
+		       created_at
+		FROM audit_events
+		WHERE ($1 = '' OR pull_request_id = $1)
+		  AND ($2 = '' OR user_id = $2)
+		  AND ($3 = '' OR team_name = $3)
+		  AND ($4 = '' OR actor = $4)
+		  AND ($5 = '' OR action = $5)
+		  AND ($6::timestamptz IS NULL OR created_at >= $6)
+		  AND ($7::timestamptz IS NULL OR created_at < $7)
+		  AND ($8::timestamptz IS NULL OR (created_at, id) < ($8, $9))
+		ORDER BY created_at DESC, id DESC
+		LIMIT $10;
+	`
+
+	rows, err := ext.Query(ctx, query,
+		filter.PullRequestID,
+		filter.UserID,
+		filter.TeamName,
+		filter.Actor,
+		filter.Action,
+		filter.From,
+		filter.To,
+		filter.AfterTime,
+		filter.AfterID,
+		filter.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	events := make([]model.AuditEvent, 0, filter.Limit)
+
+	for rows.Next() {
+		var event model.AuditEvent
+
+		if err := rows.Scan(
+			&event.ID,
+			&event.Actor,
+			&event.Action,
+			&event.PullRequestID,
+			&event.UserID,
+			&event.TeamName,
+			&event.Before,
+			&event.After,
+			&event.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+func nullableJSON(raw []byte) any {
+	if len(raw) == 0 {
+		return nil
+	}
+
+	return string(raw)
+}
@@ -0,0 +1,328 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"avito-test-assignment/internal/actor"
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+)
+
+type AuthRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewAuthRepository(db *pgxpool.Pool) *AuthRepository {
+	return &AuthRepository{db: db}
+}
+
+func (r *AuthRepository) Pool() *pgxpool.Pool {
+	return r.db
+}
+
+// SelectActorByTokenHash also marks the token as used.
+func (r *AuthRepository) SelectActorByTokenHash(ctx context.Context, ext RepoExtension, tokenHash string) (*actor.Actor, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		WITH token AS (
+		    UPDATE api_tokens
+		    SET last_used_at = now()
+		    WHERE token_hash = $1
+		      AND revoked_at IS NULL
+		    RETURNING user_id
+		)
+		SELECT u.id,
+		       u.role,
+		       ARRAY(
+		           SELECT t.team_name
+		           FROM team_lnk tl
+		           JOIN teams t ON t.id = tl.team_id
+		           WHERE tl.user_id = u.id
+		           ORDER BY t.team_name
+		       )
+		FROM token
+		JOIN users u ON u.id = token.user_id;
+	`
+
+	var a actor.Actor
+
+	if err := ext.QueryRow(ctx, query, tokenHash).Scan(&a.ID, &a.Role, &a.Teams); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrUnauthorized
+		}
+
+		return nil, err
+	}
+
+	return &a, nil
+}
+
+func (r *AuthRepository) InsertAPIToken(ctx context.Context, ext RepoExtension, userID, name, tokenHash string) (*model.APIToken, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		INSERT INTO api_tokens (user_id, name, token_hash)
+		VALUES ($1, $2, $3)
+		RETURNING id, user_id, name, created_at, last_used_at, revoked_at;
+	`
+
+	return scanAPIToken(ext.QueryRow(ctx, query, userID, name, tokenHash))
+}
+
+func (r *AuthRepository) SelectAPITokens(ctx context.Context, ext RepoExtension, userID string) ([]model.APIToken, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT id, user_id, name, created_at, last_used_at, revoked_at
+		FROM api_tokens
+		WHERE ($1 = '' OR user_id = $1)
+		ORDER BY id;
+	`
+
+	rows, err := ext.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	tokens := make([]model.APIToken, 0, listDefaultCap)
+
+	for rows.Next() {
+		token, err := scanAPIToken(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, *token)
+	}
+
+	return tokens, rows.Err()
+}
+
+func (r *AuthRepository) RevokeAPIToken(ctx context.Context, ext RepoExtension, id int64) (*model.APIToken, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		UPDATE api_tokens
+		SET revoked_at = now()
+		WHERE id = $1
+		  AND revoked_at IS NULL
+		RETURNING id, user_id, name, created_at, last_used_at, revoked_at;
+	`
+
+	token, err := scanAPIToken(ext.QueryRow(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrAPITokenNotExist
+		}
+
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (r *AuthRepository) SelectUserRole(ctx context.Context, ext RepoExtension, userID string) (string, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT role
+		FROM users
+		WHERE id = $1;
+	`
+
+	var role string
+
+	if err := ext.QueryRow(ctx, query, userID).Scan(&role); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", apperrors.ErrUserNotExist
+		}
+
+		return "", err
+	}
+
+	return role, nil
+}
+
+func (r *AuthRepository) SelectUserIsActive(ctx context.Context, ext RepoExtension, userID string) (bool, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT is_active
+		FROM users
+		WHERE id = $1;
+	`
+
+	var isActive bool
+
+	if err := ext.QueryRow(ctx, query, userID).Scan(&isActive); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return false, apperrors.ErrUserNotExist
+		}
+
+		return false, err
+	}
+
+	return isActive, nil
+}
+
+func (r *AuthRepository) UpdateUserRole(ctx context.Context, ext RepoExtension, userID, role string) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		UPDATE users
+		SET role = $1, updated_at = now()
+		WHERE id = $2;
+	`
+
+	cmd, err := ext.Exec(ctx, query, role, userID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrUserNotExist
+	}
+
+	return nil
+}
+
+func (r *AuthRepository) SelectUserTeamNames(ctx context.Context, ext RepoExtension, userID string) ([]string, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT ARRAY(
+		           SELECT t.team_name
+		           FROM team_lnk tl
+		           JOIN teams t ON t.id = tl.team_id
+		           WHERE tl.user_id = u.id
+		           ORDER BY t.team_name
+		       )
+		FROM users u
+		WHERE u.id = $1;
+	`
+
+	var teams []string
+
+	if err := ext.QueryRow(ctx, query, userID).Scan(&teams); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrUserNotExist
+		}
+
+		return nil, err
+	}
+
+	return teams, nil
+}
+
+func (r *AuthRepository) SelectCodeOwnerRuleTeamName(ctx context.Context, ext RepoExtension, ruleID int64) (string, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT t.team_name
+		FROM code_owner_rules cor
+		JOIN teams t ON t.id = cor.team_id
+		WHERE cor.id = $1;
+	`
+
+	var teamName string
+
+	if err := ext.QueryRow(ctx, query, ruleID).Scan(&teamName); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", apperrors.ErrCodeOwnerRuleNotExist
+		}
+
+		return "", err
+	}
+
+	return teamName, nil
+}
+
+func (r *AuthRepository) SelectUnavailabilityUserID(ctx context.Context, ext RepoExtension, id int64) (string, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT user_id
+		FROM user_unavailability
+		WHERE id = $1;
+	`
+
+	var userID string
+
+	if err := ext.QueryRow(ctx, query, id).Scan(&userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", apperrors.ErrUnavailabilityNotExist
+		}
+
+		return "", err
+	}
+
+	return userID, nil
+}
+
+func (r *AuthRepository) SelectPullRequestAuthorID(ctx context.Context, ext RepoExtension, pullRequestID string) (string, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT author_id
+		FROM pull_requests
+		WHERE pull_request_id = $1;
+	`
+
+	var authorID string
+
+	if err := ext.QueryRow(ctx, query, pullRequestID).Scan(&authorID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", apperrors.ErrPullRequestNotExist
+		}
+
+		return "", err
+	}
+
+	return authorID, nil
+}
+
+func scanAPIToken(row pgx.Row) (*model.APIToken, error) {
+	var token model.APIToken
+
+	if err := row.Scan(
+		&token.ID,
+		&token.UserID,
+		&token.Name,
+		&token.CreatedAt,
+		&token.LastUsedAt,
+		&token.RevokedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+)
+
+type CodeOwnerRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCodeOwnerRepository(db *pgxpool.Pool) *CodeOwnerRepository {
+	return &CodeOwnerRepository{db: db}
+}
+
+func (r *CodeOwnerRepository) Pool() *pgxpool.Pool {
+	return r.db
+}
+
+func (r *CodeOwnerRepository) InsertCodeOwnerRule(ctx context.Context, ext RepoExtension, rule *model.CodeOwnerRule) (*model.CodeOwnerRule, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		INSERT INTO code_owner_rules (team_id, pattern, owner_users, owner_teams, required)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, team_id, pattern, owner_users, owner_teams, required, created_at;
+	`
+
+	return scanCodeOwnerRule(ext.QueryRow(ctx, query, rule.TeamID, rule.Pattern, rule.Users, rule.Teams, rule.Required))
+}
+
+func (r *CodeOwnerRepository) SelectCodeOwnerRules(ctx context.Context, ext RepoExtension, teamID int) ([]model.CodeOwnerRule, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT id, team_id, pattern, owner_users, owner_teams, required, created_at
+		FROM code_owner_rules
+		WHERE team_id = $1
+		ORDER BY id;
+	`
+
+	rows, err := ext.Query(ctx, query, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	rules := make([]model.CodeOwnerRule, 0, listDefaultCap)
+
+	for rows.Next() {
+		rule, err := scanCodeOwnerRule(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		rules = append(rules, *rule)
+	}
+
+	return rules, rows.Err()
+}
+
+func (r *CodeOwnerRepository) DeleteCodeOwnerRule(ctx context.Context, ext RepoExtension, id int64) (*model.CodeOwnerRule, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		DELETE FROM code_owner_rules
+		WHERE id = $1
+		RETURNING id, team_id, pattern, owner_users, owner_teams, required, created_at;
+	`
+
+	return scanCodeOwnerRule(ext.QueryRow(ctx, query, id))
+}
+
+func scanCodeOwnerRule(row pgx.Row) (*model.CodeOwnerRule, error) {
+	var rule model.CodeOwnerRule
+
+	if err := row.Scan(
+		&rule.ID,
+		&rule.TeamID,
+		&rule.Pattern,
+		&rule.Users,
+		&rule.Teams,
+		&rule.Required,
+		&rule.CreatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrCodeOwnerRuleNotExist
+		}
+
+		return nil, err
+	}
+
+	return &rule, nil
+}
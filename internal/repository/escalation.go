@@ -0,0 +1,157 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"avito-test-assignment/internal/model"
+)
+
+type EscalationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewEscalationRepository(db *pgxpool.Pool) *EscalationRepository {
+	return &EscalationRepository{db: db}
+}
+
+func (r *EscalationRepository) Pool() *pgxpool.Pool {
+	return r.db
+}
+
+// ClaimStaleReviews skips assignments that were already escalated.
+func (r *EscalationRepository) ClaimStaleReviews(ctx context.Context, ext RepoExtension, limit int) ([]model.StaleReview, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT prr.pull_request_id, t.id, t.team_name, prr.reviewer_id, prr.assigned_at, t.stale_review_action
+		FROM pr_reviewers prr
+		JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id
+		JOIN teams t ON t.id = pr.team_id
+		WHERE pr.status IN ('OPEN', 'REOPENED')
+		  AND t.review_sla_minutes IS NOT NULL
+		  AND prr.assigned_at <= now() - make_interval(mins => t.review_sla_minutes)
+		  AND NOT EXISTS (
+		      SELECT 1
+		      FROM pr_reviews rv
+		      WHERE rv.pull_request_id = prr.pull_request_id
+		        AND rv.reviewer_id = prr.reviewer_id
+		        AND rv.created_at >= prr.assigned_at
+		  )
+		  AND NOT EXISTS (
+		      SELECT 1
+		      FROM review_escalations e
+		      WHERE e.pull_request_id = prr.pull_request_id
+		        AND e.reviewer_id = prr.reviewer_id
+		        AND e.assigned_at = prr.assigned_at
+		  )
+		ORDER BY prr.assigned_at, prr.pull_request_id, prr.reviewer_id
+		LIMIT $1
+		FOR UPDATE OF pr SKIP LOCKED;
+	`
+
+	rows, err := ext.Query(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	stale := make([]model.StaleReview, 0, listDefaultCap)
+
+	for rows.Next() {
+		var s model.StaleReview
+
+		if err := rows.Scan(&s.PullRequestID, &s.TeamID, &s.TeamName, &s.ReviewerID, &s.AssignedAt, &s.Action); err != nil {
+			return nil, err
+		}
+
+		stale = append(stale, s)
+	}
+
+	return stale, rows.Err()
+}
+
+func (r *EscalationRepository) InsertEscalation(
+	ctx context.Context,
+	ext RepoExtension,
+	stale *model.StaleReview,
+	newReviewerID string,
+) (*model.ReviewEscalation, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		INSERT INTO review_escalations (pull_request_id, team_id, reviewer_id, assigned_at, action, new_reviewer_id)
+		VALUES ($1, $2, $3, $4, $5, NULLIF($6, ''))
+		RETURNING created_at;
+	`
+
+	escalation := model.ReviewEscalation{
+		PullRequestID: stale.PullRequestID,
+		TeamName:      stale.TeamName,
+		ReviewerID:    stale.ReviewerID,
+		AssignedAt:    stale.AssignedAt,
+		Action:        stale.Action,
+		NewReviewerID: newReviewerID,
+	}
+
+	err := ext.QueryRow(
+		ctx,
+		query,
+		stale.PullRequestID,
+		stale.TeamID,
+		stale.ReviewerID,
+		stale.AssignedAt,
+		stale.Action,
+		newReviewerID,
+	).Scan(&escalation.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &escalation, nil
+}
+
+func (r *EscalationRepository) GetEscalationStats(ctx context.Context, ext RepoExtension) ([]model.EscalationStats, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT t.team_name,
+		       COUNT(*) FILTER (WHERE e.action = 'REASSIGN' AND e.new_reviewer_id IS NOT NULL) AS reassigned,
+		       COUNT(*) FILTER (WHERE e.action = 'ADD_REVIEWER' AND e.new_reviewer_id IS NOT NULL) AS reviewers_added,
+		       COUNT(*) FILTER (WHERE e.new_reviewer_id IS NULL) AS no_candidate,
+		       MAX(e.created_at) AS last_escalated_at
+		FROM review_escalations e
+		JOIN teams t ON t.id = e.team_id
+		GROUP BY t.team_name
+		ORDER BY t.team_name;
+	`
+
+	rows, err := ext.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	stats := make([]model.EscalationStats, 0, listDefaultCap)
+
+	for rows.Next() {
+		var s model.EscalationStats
+
+		if err := rows.Scan(&s.TeamName, &s.Reassigned, &s.ReviewersAdded, &s.NoCandidate, &s.LastEscalatedAt); err != nil {
+			return nil, err
+		}
+
+		stats = append(stats, s)
+	}
+
+	return stats, rows.Err()
+}
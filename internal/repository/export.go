@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"avito-test-assignment/internal/model"
+)
+
+type ExportRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewExportRepository(db *pgxpool.Pool) *ExportRepository {
+	return &ExportRepository{db: db}
+}
+
+func (r *ExportRepository) Pool() *pgxpool.Pool {
+	return r.db
+}
+
+// StreamPullRequests ignores cursor and limit of the filter.
+func (r *ExportRepository) StreamPullRequests(
+	ctx context.Context,
+	ext RepoExtension,
+	filter *model.PullRequestFilter,
+	fn func(*model.PullRequestExportRow) error,
+) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT pr.pull_request_id,
+		       pr.pull_request_name,
+		       pr.author_id,
+		       pr.status,
+		       COALESCE(t.team_name, ''),
+		       ARRAY(
+		           SELECT prr.reviewer_id
+		           FROM pr_reviewers prr
+		           WHERE prr.pull_request_id = pr.pull_request_id
+		           ORDER BY prr.assigned_at, prr.reviewer_id
+		       ) AS assigned,
+		       pr.created_at,
+		       pr.merged_at,
+		       pr.merged_by
+		FROM pull_requests pr
+		LEFT JOIN teams t ON t.id = pr.team_id
+		WHERE ($1 = '' OR pr.author_id = $1)
+		  AND ($2 = '' OR EXISTS (
+		      SELECT 1
+		      FROM pr_reviewers prr
+		      WHERE prr.pull_request_id = pr.pull_request_id
+		        AND prr.reviewer_id = $2
+		  ))
+		  AND ($3 = '' OR t.team_name = $3)
+		  AND ($4 = '' OR pr.status::text = $4)
+		  AND ($5::timestamptz IS NULL OR pr.created_at >= $5)
+		  AND ($6::timestamptz IS NULL OR pr.created_at < $6)
+		  AND ($7::timestamptz IS NULL OR pr.merged_at >= $7)
+		  AND ($8::timestamptz IS NULL OR pr.merged_at < $8)
+		ORDER BY pr.created_at, pr.pull_request_id;
+	`
+
+	rows, err := ext.Query(ctx, query,
+		filter.AuthorID,
+		filter.ReviewerID,
+		filter.TeamName,
+		filter.Status,
+		filter.CreatedFrom,
+		filter.CreatedTo,
+		filter.MergedFrom,
+		filter.MergedTo,
+	)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var pr model.PullRequestExportRow
+
+		if err := rows.Scan(
+			&pr.PullRequestID,
+			&pr.PullRequestName,
+			&pr.AuthorID,
+			&pr.Status,
+			&pr.TeamName,
+			&pr.Reviewers,
+			&pr.CreatedAt,
+			&pr.MergedAt,
+			&pr.MergedBy,
+		); err != nil {
+			return err
+		}
+
+		if err := fn(&pr); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
+// StreamAssignmentEvents ignores actor, action, cursor and limit of the filter.
+func (r *ExportRepository) StreamAssignmentEvents(
+	ctx context.Context,
+	ext RepoExtension,
+	filter *model.AuditFilter,
+	fn func(*model.AssignmentExportRow) error,
+) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT id,
+		       created_at,
+		       actor,
+		       action,
+		       COALESCE(pull_request_id, ''),
+		       COALESCE(user_id, ''),
+		       COALESCE(team_name, ''),
+		       COALESCE(after->>'reason', ''),
+		       ARRAY(
+		           SELECT jsonb_array_elements_text(after->'replaced_by')
+		           WHERE jsonb_typeof(after->'replaced_by') = 'array'
+		       ),
+		       ARRAY(
+		           SELECT jsonb_array_elements_text(before->'reviewers')
+		           WHERE jsonb_typeof(before->'reviewers') = 'array'
+		       ),
+		       ARRAY(
+		           SELECT jsonb_array_elements_text(after->'reviewers')
+		           WHERE jsonb_typeof(after->'reviewers') = 'array'
+		       )
+		FROM audit_events
+		WHERE action IN ('REVIEWERS_ASSIGNED', 'REVIEWER_REASSIGNED', 'REVIEWER_ADDED', 'REVIEWER_REMOVED')
+		  AND ($1 = '' OR pull_request_id = $1)
+		  AND ($2 = '' OR user_id = $2)
+		  AND ($3 = '' OR team_name = $3)
+		  AND ($4::timestamptz IS NULL OR created_at >= $4)
+		  AND ($5::timestamptz IS NULL OR created_at < $5)
+		ORDER BY created_at, id;
+	`
+
+	rows, err := ext.Query(ctx, query, filter.PullRequestID, filter.UserID, filter.TeamName, filter.From, filter.To)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var event model.AssignmentExportRow
+
+		if err := rows.Scan(
+			&event.ID,
+			&event.CreatedAt,
+			&event.Actor,
+			&event.Action,
+			&event.PullRequestID,
+			&event.UserID,
+			&event.TeamName,
+			&event.Reason,
+			&event.ReplacedBy,
+			&event.ReviewersBefore,
+			&event.ReviewersAfter,
+		); err != nil {
+			return err
+		}
+
+		if err := fn(&event); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
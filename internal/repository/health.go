@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"avito-test-assignment/internal/model"
+)
+
+type HealthRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewHealthRepository(db *pgxpool.Pool) *HealthRepository {
+	return &HealthRepository{db: db}
+}
+
+func (r *HealthRepository) Pool() *pgxpool.Pool {
+	return r.db
+}
+
+func (r *HealthRepository) Ping(ctx context.Context) error {
+	return r.db.Ping(ctx)
+}
+
+// SelectMigrationVersion returns 0 for a database without applied migrations.
+func (r *HealthRepository) SelectMigrationVersion(ctx context.Context, ext RepoExtension) (uint64, bool, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT version, dirty
+		FROM schema_migrations
+		LIMIT 1;
+	`
+
+	var (
+		version int64
+		dirty   bool
+	)
+
+	if err := ext.QueryRow(ctx, query).Scan(&version, &dirty); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, false, nil
+		}
+
+		return 0, false, err
+	}
+
+	return uint64(version), dirty, nil
+}
+
+func (r *HealthRepository) PoolStats() model.PoolStats {
+	stat := r.db.Stat()
+
+	return model.PoolStats{
+		AcquiredConns: stat.AcquiredConns(),
+		IdleConns:     stat.IdleConns(),
+		TotalConns:    stat.TotalConns(),
+		MaxConns:      stat.MaxConns(),
+	}
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"avito-test-assignment/internal/model"
+)
+
+type IdempotencyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewIdempotencyRepository(db *pgxpool.Pool) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+func (r *IdempotencyRepository) Pool() *pgxpool.Pool {
+	return r.db
+}
+
+// Reserve returns the live record holding the key, if any, with reserved false.
+// An unfinished reservation whose lease has run out is taken over.
+func (r *IdempotencyRepository) Reserve(
+	ctx context.Context,
+	ext RepoExtension,
+	key, route, actorID, requestHash string,
+	ttl, lease time.Duration,
+) (record *model.IdempotencyRecord, reserved bool, err error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const reserveQuery = `
+		INSERT INTO idempotency_keys (idempotency_key, route, actor_id, request_hash, expires_at, locked_until)
+		VALUES ($1, $2, $3, $4, now() + $5::interval, now() + $6::interval)
+		ON CONFLICT (idempotency_key, route, actor_id) DO UPDATE
+		SET request_hash = EXCLUDED.request_hash,
+		    status_code = NULL,
+		    response_body = NULL,
+		    created_at = now(),
+		    expires_at = EXCLUDED.expires_at,
+		    locked_until = EXCLUDED.locked_until
+		WHERE idempotency_keys.expires_at <= now()
+		   OR (idempotency_keys.status_code IS NULL AND idempotency_keys.locked_until <= now())
+		RETURNING idempotency_key;
+	`
+
+	var claimed string
+
+	err = ext.QueryRow(ctx, reserveQuery, key, route, actorID, requestHash, ttl, lease).Scan(&claimed)
+	if err == nil {
+		return nil, true, nil
+	}
+
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, false, err
+	}
+
+	const selectQuery = `
+		SELECT idempotency_key, route, actor_id, request_hash, status_code, response_body, expires_at
+		FROM idempotency_keys
+		WHERE idempotency_key = $1 AND route = $2 AND actor_id = $3;
+	`
+
+	var rec model.IdempotencyRecord
+
+	if err = ext.QueryRow(ctx, selectQuery, key, route, actorID).Scan(
+		&rec.Key,
+		&rec.Route,
+		&rec.ActorID,
+		&rec.RequestHash,
+		&rec.StatusCode,
+		&rec.ResponseBody,
+		&rec.ExpiresAt,
+	); err != nil {
+		return nil, false, err
+	}
+
+	return &rec, false, nil
+}
+
+func (r *IdempotencyRepository) Complete(
+	ctx context.Context,
+	ext RepoExtension,
+	key, route, actorID string,
+	statusCode int,
+	body []byte,
+) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		UPDATE idempotency_keys
+		SET status_code = $4, response_body = $5
+		WHERE idempotency_key = $1 AND route = $2 AND actor_id = $3 AND status_code IS NULL;
+	`
+
+	_, err := ext.Exec(ctx, query, key, route, actorID, statusCode, body)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, ext RepoExtension, key, route, actorID string) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		DELETE FROM idempotency_keys
+		WHERE idempotency_key = $1 AND route = $2 AND actor_id = $3 AND status_code IS NULL;
+	`
+
+	_, err := ext.Exec(ctx, query, key, route, actorID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *IdempotencyRepository) DeleteExpired(ctx context.Context, ext RepoExtension) (int64, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		DELETE FROM idempotency_keys
+		WHERE expires_at <= now();
+	`
+
+	cmd, err := ext.Exec(ctx, query)
+	if err != nil {
+		return 0, err
+	}
+
+	return cmd.RowsAffected(), nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestIdempotencyReserveTakesOverExpiredLease(t *testing.T) {
+	repo := NewIdempotencyRepository(testPool(t))
+	ctx := context.Background()
+
+	if _, reserved, err := repo.Reserve(ctx, nil, "k1", "/pullRequest/create", "u1", "h1", time.Hour, 100*time.Millisecond); err != nil || !reserved {
+		t.Fatalf("first reserve = %v, %v; want reserved", reserved, err)
+	}
+
+	record, reserved, err := repo.Reserve(ctx, nil, "k1", "/pullRequest/create", "u1", "h1", time.Hour, time.Minute)
+	if err != nil || reserved || record.StatusCode != nil {
+		t.Fatalf("reserve under lease = %+v, %v, %v; want the in-progress record", record, reserved, err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	if _, reserved, err := repo.Reserve(ctx, nil, "k1", "/pullRequest/create", "u1", "h1", time.Hour, time.Minute); err != nil || !reserved {
+		t.Fatalf("reserve after lease = %v, %v; want reserved", reserved, err)
+	}
+
+	if err := repo.Complete(ctx, nil, "k1", "/pullRequest/create", "u1", 201, []byte(`{}`)); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+
+	time.Sleep(200 * time.Millisecond)
+
+	record, reserved, err = repo.Reserve(ctx, nil, "k1", "/pullRequest/create", "u1", "h1", time.Hour, time.Minute)
+	if err != nil || reserved || record.StatusCode == nil || *record.StatusCode != 201 {
+		t.Fatalf("reserve after complete = %+v, %v, %v; want the stored response", record, reserved, err)
+	}
+}
@@ -24,24 +24,32 @@ func (r *PullRequestRepository) Pool() *pgxpool.Pool {
 	return r.db
 }
 
-func (r *PullRequestRepository) InsertPullRequest(ctx context.Context, ext RepoExtension, id, name, authorID string) (*model.PullRequest, error) {
+func (r *PullRequestRepository) InsertPullRequest(
+	ctx context.Context,
+	ext RepoExtension,
+	id, name, authorID, status string,
+	teamID int,
+	changedPaths []string,
+) (*model.PullRequest, error) {
 	if ext == nil {
 		ext = r.db
 	}
 
 	const query = `
-		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id)
-		VALUES ($1, $2, $3)
-		RETURNING pull_request_id, pull_request_name, author_id, status, created_at, merged_at;
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, status, team_id, changed_paths)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING pull_request_id, pull_request_name, author_id, status, team_id, changed_paths, created_at, merged_at;
 	`
 
 	var pr model.PullRequest
 
-	err := ext.QueryRow(ctx, query, id, name, authorID).Scan(
+	err := ext.QueryRow(ctx, query, id, name, authorID, status, teamID, changedPaths).Scan(
 		&pr.PullRequestID,
 		&pr.PullRequestName,
 		&pr.AuthorID,
 		&pr.Status,
+		&pr.TeamID,
+		&pr.ChangedPaths,
 		&pr.CreatedAt,
 		&pr.MergedAt,
 	)
@@ -58,16 +66,31 @@ func (r *PullRequestRepository) InsertPullRequest(ctx context.Context, ext RepoE
 }
 
 func (r *PullRequestRepository) SelectPullRequestByID(ctx context.Context, ext RepoExtension, id string) (*model.PullRequest, error) {
-	if ext == nil {
-		ext = r.db
-	}
-
 	const query = `
-		SELECT pull_request_id, pull_request_name, author_id, status, created_at, merged_at
+		SELECT pull_request_id, pull_request_name, author_id, status, team_id, changed_paths, created_at, merged_at, merged_by
 		FROM pull_requests
 		WHERE pull_request_id = $1;
 	`
 
+	return r.selectPullRequest(ctx, ext, query, id)
+}
+
+func (r *PullRequestRepository) SelectPullRequestByIDForUpdate(ctx context.Context, ext RepoExtension, id string) (*model.PullRequest, error) {
+	const query = `
+		SELECT pull_request_id, pull_request_name, author_id, status, team_id, changed_paths, created_at, merged_at, merged_by
+		FROM pull_requests
+		WHERE pull_request_id = $1
+		FOR UPDATE;
+	`
+
+	return r.selectPullRequest(ctx, ext, query, id)
+}
+
+func (r *PullRequestRepository) selectPullRequest(ctx context.Context, ext RepoExtension, query, id string) (*model.PullRequest, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
 	var pr model.PullRequest
 
 	err := ext.QueryRow(ctx, query, id).Scan(
@@ -75,8 +98,11 @@ func (r *PullRequestRepository) SelectPullRequestByID(ctx context.Context, ext R
 		&pr.PullRequestName,
 		&pr.AuthorID,
 		&pr.Status,
+		&pr.TeamID,
+		&pr.ChangedPaths,
 		&pr.CreatedAt,
 		&pr.MergedAt,
+		&pr.MergedBy,
 	)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -89,37 +115,130 @@ func (r *PullRequestRepository) SelectPullRequestByID(ctx context.Context, ext R
 	return &pr, nil
 }
 
-func (r *PullRequestRepository) SetReviewers(ctx context.Context, ext RepoExtension, authorID, prID string) ([]string, error) {
+func (r *PullRequestRepository) GetReviewerCandidatesForTeam(ctx context.Context, ext RepoExtension, teamID int, authorID string) ([]model.ReviewerCandidate, error) {
 	if ext == nil {
 		ext = r.db
 	}
 
 	const query = `
-		WITH team_members AS (
-		    SELECT u.id AS user_id,
-		           COUNT(pr.pull_request_id) AS open_prs
-		    FROM team_lnk tl
-		    JOIN users u ON u.id = tl.user_id
-		    LEFT JOIN pr_reviewers prr ON prr.reviewer_id = u.id
-		    LEFT JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id AND pr.status = 'OPEN'
-		    WHERE tl.team_id IN (
-		        SELECT team_id
-		        FROM team_lnk
-		        WHERE user_id = $1
+		SELECT rl.user_id, rl.open_reviews
+		FROM reviewer_load rl
+		JOIN team_lnk tl ON tl.user_id = rl.user_id
+		WHERE tl.team_id = $1
+		AND rl.user_id <> $2
+		AND rl.available
+		AND (rl.max_open_reviews IS NULL OR rl.open_reviews < rl.max_open_reviews)
+		ORDER BY rl.open_reviews ASC, rl.user_id;
+	`
+
+	rows, err := ext.Query(ctx, query, teamID, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return scanReviewerCandidates(rows)
+}
+
+func (r *PullRequestRepository) GetCandidatesAmong(
+	ctx context.Context,
+	ext RepoExtension,
+	userIDs, teamNames []string,
+	authorID string,
+) ([]model.ReviewerCandidate, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT rl.user_id, rl.open_reviews
+		FROM reviewer_load rl
+		WHERE (
+		    rl.user_id = ANY($1)
+		    OR rl.user_id IN (
+		        SELECT tl.user_id
+		        FROM team_lnk tl
+		        JOIN teams t ON t.id = tl.team_id
+		        WHERE t.team_name = ANY($2)
 		    )
-		    AND u.id <> $1
-			AND u.is_active = true
-		    GROUP BY u.id
-		    ORDER BY open_prs ASC
-		    LIMIT 2
 		)
+		AND rl.user_id <> $3
+		AND rl.available
+		AND (rl.max_open_reviews IS NULL OR rl.open_reviews < rl.max_open_reviews)
+		ORDER BY rl.open_reviews ASC, rl.user_id;
+	`
+
+	rows, err := ext.Query(ctx, query, userIDs, teamNames, authorID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return scanReviewerCandidates(rows)
+}
+
+// LockReviewersBelowCapacity re-reads the load after locking so assignments committed while waiting count.
+func (r *PullRequestRepository) LockReviewersBelowCapacity(ctx context.Context, ext RepoExtension, userIDs []string) ([]string, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const lockQuery = `
+		SELECT id
+		FROM users
+		WHERE id = ANY($1)
+		ORDER BY id
+		FOR UPDATE;
+	`
+
+	if _, err := ext.Exec(ctx, lockQuery, userIDs); err != nil {
+		return nil, err
+	}
+
+	const query = `
+		SELECT rl.user_id
+		FROM reviewer_load rl
+		WHERE rl.user_id = ANY($1)
+		AND rl.available
+		AND (rl.max_open_reviews IS NULL OR rl.open_reviews < rl.max_open_reviews);
+	`
+
+	rows, err := ext.Query(ctx, query, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	ids := make([]string, 0, len(userIDs))
+
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func (r *PullRequestRepository) SetReviewers(ctx context.Context, ext RepoExtension, prID string, reviewerIDs []string) ([]string, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
 		INSERT INTO pr_reviewers (pull_request_id, reviewer_id)
-		SELECT $2, user_id
-		FROM team_members
+		SELECT $1, reviewer_id
+		FROM unnest($2::text[]) AS reviewer_id
 		RETURNING reviewer_id;
 	`
 
-	rows, err := ext.Query(ctx, query, authorID, prID)
+	rows, err := ext.Query(ctx, query, prID, reviewerIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -137,7 +256,7 @@ func (r *PullRequestRepository) SetReviewers(ctx context.Context, ext RepoExtens
 		reviewers = append(reviewers, id)
 	}
 
-	return reviewers, nil
+	return reviewers, rows.Err()
 }
 
 func (r *PullRequestRepository) SelectPullRequestsByUserID(ctx context.Context, ext RepoExtension, userID string) ([]*model.PullRequest, error) {
@@ -146,7 +265,7 @@ func (r *PullRequestRepository) SelectPullRequestsByUserID(ctx context.Context,
 	}
 
 	const query = `
-		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.created_at, pr.merged_at
+		SELECT pr.pull_request_id, pr.pull_request_name, pr.author_id, pr.status, pr.team_id, pr.created_at, pr.merged_at
 		FROM pull_requests pr
 		JOIN pr_reviewers r ON r.pull_request_id = pr.pull_request_id
 		WHERE r.reviewer_id = $1;
@@ -169,6 +288,7 @@ func (r *PullRequestRepository) SelectPullRequestsByUserID(ctx context.Context,
 			&pr.PullRequestName,
 			&pr.AuthorID,
 			&pr.Status,
+			&pr.TeamID,
 			&pr.CreatedAt,
 			&pr.MergedAt,
 		); err != nil {
@@ -181,7 +301,7 @@ func (r *PullRequestRepository) SelectPullRequestsByUserID(ctx context.Context,
 	return prs, nil
 }
 
-func (r *PullRequestRepository) MergePullRequest(ctx context.Context, ext RepoExtension, prID string) error {
+func (r *PullRequestRepository) MergePullRequest(ctx context.Context, ext RepoExtension, prID, prevStatus, mergedBy string) error {
 	if ext == nil {
 		ext = r.db
 	}
@@ -189,20 +309,46 @@ func (r *PullRequestRepository) MergePullRequest(ctx context.Context, ext RepoEx
 	const query = `
 		UPDATE pull_requests
 		SET status = 'MERGED',
-		    merged_at = now()
+		    merged_at = now(),
+		    merged_by = NULLIF($2, '')
 		WHERE pull_request_id = $1
+		  AND status = $3
 	`
 
-	cmd, err := ext.Exec(ctx, query, prID)
+	cmd, err := ext.Exec(ctx, query, prID, mergedBy, prevStatus)
 	if err != nil {
 		return err
 	}
 
 	if cmd.RowsAffected() == 0 {
-		return apperrors.ErrPullRequestNotExist
+		return apperrors.ErrPullRequestStatusChanged
 	}
 
-	return err
+	return nil
+}
+
+func (r *PullRequestRepository) UpdatePullRequestStatus(ctx context.Context, ext RepoExtension, prID, prevStatus, status string) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		UPDATE pull_requests
+		SET status = $2
+		WHERE pull_request_id = $1
+		  AND status = $3
+	`
+
+	cmd, err := ext.Exec(ctx, query, prID, status, prevStatus)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrPullRequestStatusChanged
+	}
+
+	return nil
 }
 
 func (r *PullRequestRepository) GetAssignedReviewers(ctx context.Context, ext RepoExtension, prID string) ([]string, error) {
@@ -258,34 +404,40 @@ func (r *PullRequestRepository) GetPRStatus(ctx context.Context, ext RepoExtensi
 	return status, nil
 }
 
-func (r *PullRequestRepository) GetReviewerCandidates(ctx context.Context, ext RepoExtension, oldReviewerID, prID string) ([]string, error) {
+func (r *PullRequestRepository) GetReviewerCandidates(ctx context.Context, ext RepoExtension, oldReviewerID, prID string) ([]model.ReviewerCandidate, error) {
 	if ext == nil {
 		ext = r.db
 	}
 
 	const query = `
-        WITH old_team AS (
+        WITH pr_team AS (
+            SELECT team_id
+            FROM pull_requests
+            WHERE pull_request_id = $2
+              AND team_id IS NOT NULL
+        ),
+        old_team AS (
+            SELECT team_id FROM pr_team
+            UNION
             SELECT team_id
             FROM team_lnk
             WHERE user_id = $1
+              AND NOT EXISTS (SELECT 1 FROM pr_team)
         )
 
-        SELECT u.id AS reviewer_id
-        FROM team_lnk tl
-        JOIN users u ON u.id = tl.user_id
-        LEFT JOIN pr_reviewers prr2 ON prr2.reviewer_id = u.id
-        LEFT JOIN pull_requests pr2 ON pr2.pull_request_id = prr2.pull_request_id
-             AND pr2.status = 'OPEN'
+        SELECT DISTINCT rl.user_id, rl.open_reviews
+        FROM reviewer_load rl
+        JOIN team_lnk tl ON tl.user_id = rl.user_id
         WHERE tl.team_id IN (SELECT team_id FROM old_team)
-          AND u.id <> $1
-          AND u.is_active = true
-          AND u.id NOT IN (
+          AND rl.user_id <> $1
+          AND rl.available
+          AND (rl.max_open_reviews IS NULL OR rl.open_reviews < rl.max_open_reviews)
+          AND rl.user_id NOT IN (
               SELECT reviewer_id
               FROM pr_reviewers
               WHERE pull_request_id = $2
           )
-        GROUP BY u.id
-        ORDER BY COUNT(pr2.pull_request_id) ASC;
+        ORDER BY rl.open_reviews ASC, rl.user_id;
     `
 
 	rows, err := ext.Query(ctx, query, oldReviewerID, prID)
@@ -295,18 +447,7 @@ func (r *PullRequestRepository) GetReviewerCandidates(ctx context.Context, ext R
 
 	defer rows.Close()
 
-	list := make([]string, 0, listDefaultCap)
-
-	for rows.Next() {
-		var id string
-		if err := rows.Scan(&id); err != nil {
-			return nil, err
-		}
-
-		list = append(list, id)
-	}
-
-	return list, nil
+	return scanReviewerCandidates(rows)
 }
 
 func (r *PullRequestRepository) RemoveReviewer(ctx context.Context, ext RepoExtension, prID, reviewerID string) error {
@@ -369,6 +510,180 @@ func (r *PullRequestRepository) IsReviewerAssigned(ctx context.Context, ext Repo
 	return exists, nil
 }
 
+func (r *PullRequestRepository) InsertReview(ctx context.Context, ext RepoExtension, prID, reviewerID, verdict, comment string) (*model.Review, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		INSERT INTO pr_reviews (pull_request_id, reviewer_id, verdict, comment)
+		VALUES ($1, $2, $3, $4)
+		RETURNING reviewer_id, verdict, comment, created_at;
+	`
+
+	var review model.Review
+
+	err := ext.QueryRow(ctx, query, prID, reviewerID, verdict, comment).Scan(
+		&review.ReviewerID,
+		&review.Verdict,
+		&review.Comment,
+		&review.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return &review, nil
+}
+
+func (r *PullRequestRepository) GetLatestReviews(ctx context.Context, ext RepoExtension, prID string) ([]model.Review, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT DISTINCT ON (reviewer_id) reviewer_id, verdict, comment, created_at
+		FROM pr_reviews
+		WHERE pull_request_id = $1
+		ORDER BY reviewer_id, created_at DESC, id DESC
+	`
+
+	rows, err := ext.Query(ctx, query, prID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	reviews := make([]model.Review, 0, listDefaultCap)
+
+	for rows.Next() {
+		var review model.Review
+
+		if err := rows.Scan(&review.ReviewerID, &review.Verdict, &review.Comment, &review.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		reviews = append(reviews, review)
+	}
+
+	return reviews, rows.Err()
+}
+
+func (r *PullRequestRepository) CountApprovals(ctx context.Context, ext RepoExtension, prID string) (int, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT COUNT(*)
+		FROM (
+		    SELECT DISTINCT ON (rv.reviewer_id) rv.verdict
+		    FROM pr_reviews rv
+		    JOIN pr_reviewers prr ON prr.pull_request_id = rv.pull_request_id
+		         AND prr.reviewer_id = rv.reviewer_id
+		    WHERE rv.pull_request_id = $1
+		    ORDER BY rv.reviewer_id, rv.created_at DESC, rv.id DESC
+		) latest
+		WHERE latest.verdict = 'APPROVED'
+	`
+
+	var count int
+
+	if err := ext.QueryRow(ctx, query, prID).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *PullRequestRepository) SelectPullRequests(ctx context.Context, ext RepoExtension, filter *model.PullRequestFilter) ([]*model.PullRequest, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT pr.pull_request_id,
+		       pr.pull_request_name,
+		       pr.author_id,
+		       pr.status,
+		       pr.team_id,
+		       ARRAY(
+		           SELECT prr.reviewer_id
+		           FROM pr_reviewers prr
+		           WHERE prr.pull_request_id = pr.pull_request_id
+		           ORDER BY prr.assigned_at, prr.reviewer_id
+		       ) AS assigned,
+		       pr.created_at,
+		       pr.merged_at,
+		       pr.merged_by
+		FROM pull_requests pr
+		WHERE ($1 = '' OR pr.author_id = $1)
+		  AND ($2 = '' OR EXISTS (
+		      SELECT 1
+		      FROM pr_reviewers prr
+		      WHERE prr.pull_request_id = pr.pull_request_id
+		        AND prr.reviewer_id = $2
+		  ))
+		  AND ($3 = '' OR pr.team_id IN (
+		      SELECT t.id
+		      FROM teams t
+		      WHERE t.team_name = $3
+		  ))
+		  AND ($4 = '' OR pr.status::text = $4)
+		  AND ($5::timestamptz IS NULL OR pr.created_at >= $5)
+		  AND ($6::timestamptz IS NULL OR pr.created_at < $6)
+		  AND ($7::timestamptz IS NULL OR pr.merged_at >= $7)
+		  AND ($8::timestamptz IS NULL OR pr.merged_at < $8)
+		  AND ($9::timestamptz IS NULL OR (pr.created_at, pr.pull_request_id) < ($9, $10))
+		ORDER BY pr.created_at DESC, pr.pull_request_id DESC
+		LIMIT $11;
+	`
+
+	rows, err := ext.Query(ctx, query,
+		filter.AuthorID,
+		filter.ReviewerID,
+		filter.TeamName,
+		filter.Status,
+		filter.CreatedFrom,
+		filter.CreatedTo,
+		filter.MergedFrom,
+		filter.MergedTo,
+		filter.AfterTime,
+		filter.AfterID,
+		filter.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	prs := make([]*model.PullRequest, 0, filter.Limit)
+
+	for rows.Next() {
+		var pr model.PullRequest
+
+		if err := rows.Scan(
+			&pr.PullRequestID,
+			&pr.PullRequestName,
+			&pr.AuthorID,
+			&pr.Status,
+			&pr.TeamID,
+			&pr.Assigned,
+			&pr.CreatedAt,
+			&pr.MergedAt,
+			&pr.MergedBy,
+		); err != nil {
+			return nil, err
+		}
+
+		prs = append(prs, &pr)
+	}
+
+	return prs, rows.Err()
+}
+
 func (r *PullRequestRepository) GetReviewerStats(ctx context.Context, ext RepoExtension) ([]model.ReviewerStats, error) {
 	if ext == nil {
 		ext = r.db
@@ -435,3 +750,19 @@ func (r *PullRequestRepository) GetPRStats(ctx context.Context, ext RepoExtensio
 
 	return stats, nil
 }
+
+func scanReviewerCandidates(rows pgx.Rows) ([]model.ReviewerCandidate, error) {
+	candidates := make([]model.ReviewerCandidate, 0, listDefaultCap)
+
+	for rows.Next() {
+		var c model.ReviewerCandidate
+
+		if err := rows.Scan(&c.UserID, &c.OpenReviews); err != nil {
+			return nil, err
+		}
+
+		candidates = append(candidates, c)
+	}
+
+	return candidates, rows.Err()
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+)
+
+func TestConcurrentMergeAndClose(t *testing.T) {
+	pool := testPool(t)
+	repo := NewPullRequestRepository(pool)
+	ctx := context.Background()
+
+	mustExec(t, pool, `INSERT INTO teams (id, team_name) VALUES (1, 'backend')`)
+	mustExec(t, pool, `INSERT INTO users (id, username) VALUES ('u1', 'Alice')`)
+	mustExec(t, pool, `
+		INSERT INTO pull_requests (pull_request_id, pull_request_name, author_id, team_id, status)
+		VALUES ('pr-1', 'Add search', 'u1', 1, 'OPEN')`)
+
+	mergeTx, err := pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+
+	defer func() { _ = mergeTx.Rollback(ctx) }()
+
+	merging, err := repo.SelectPullRequestByIDForUpdate(ctx, mergeTx, "pr-1")
+	if err != nil {
+		t.Fatalf("lock for merge: %v", err)
+	}
+
+	closeTx, err := pool.Begin(ctx)
+	if err != nil {
+		t.Fatalf("begin: %v", err)
+	}
+
+	defer func() { _ = closeTx.Rollback(ctx) }()
+
+	type result struct {
+		pr  *model.PullRequest
+		err error
+	}
+
+	locked := make(chan result, 1)
+
+	go func() {
+		pr, err := repo.SelectPullRequestByIDForUpdate(ctx, closeTx, "pr-1")
+		locked <- result{pr, err}
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("close locked the pull request while the merge held it")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	if err := repo.MergePullRequest(ctx, mergeTx, "pr-1", merging.Status, "u1"); err != nil {
+		t.Fatalf("merge: %v", err)
+	}
+
+	if err := mergeTx.Commit(ctx); err != nil {
+		t.Fatalf("commit merge: %v", err)
+	}
+
+	closing := <-locked
+	if closing.err != nil {
+		t.Fatalf("lock for close: %v", closing.err)
+	}
+
+	if closing.pr.Status != "MERGED" {
+		t.Fatalf("close saw status %s after the merge, want MERGED", closing.pr.Status)
+	}
+
+	err = repo.UpdatePullRequestStatus(ctx, closeTx, "pr-1", merging.Status, "CLOSED")
+	if !errors.Is(err, apperrors.ErrPullRequestStatusChanged) {
+		t.Fatalf("close from stale status: got %v, want %v", err, apperrors.ErrPullRequestStatusChanged)
+	}
+
+	err = repo.MergePullRequest(ctx, pool, "pr-1", merging.Status, "u1")
+	if !errors.Is(err, apperrors.ErrPullRequestStatusChanged) {
+		t.Fatalf("merge from stale status: got %v, want %v", err, apperrors.ErrPullRequestStatusChanged)
+	}
+
+	pr, err := repo.SelectPullRequestByID(ctx, nil, "pr-1")
+	if err != nil {
+		t.Fatalf("select: %v", err)
+	}
+
+	if pr.Status != "MERGED" {
+		t.Fatalf("status = %s, want MERGED", pr.Status)
+	}
+}
@@ -0,0 +1,269 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+)
+
+type ReviewerPoolRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewReviewerPoolRepository(db *pgxpool.Pool) *ReviewerPoolRepository {
+	return &ReviewerPoolRepository{db: db}
+}
+
+func (r *ReviewerPoolRepository) Pool() *pgxpool.Pool {
+	return r.db
+}
+
+const selectReviewerPoolColumns = `
+	SELECT p.id,
+	       p.pool_name,
+	       COALESCE((
+	           SELECT array_agg(m.user_id ORDER BY m.user_id)
+	           FROM reviewer_pool_members m
+	           WHERE m.pool_id = p.id
+	       ), '{}') AS members,
+	       COALESCE((
+	           SELECT json_agg(json_build_object('team_name', t.team_name, 'mode', trp.mode) ORDER BY t.team_name)
+	           FROM team_reviewer_pools trp
+	           JOIN teams t ON t.id = trp.team_id
+	           WHERE trp.pool_id = p.id
+	       ), '[]') AS teams,
+	       p.created_at
+	FROM reviewer_pools p
+`
+
+func (r *ReviewerPoolRepository) InsertReviewerPool(ctx context.Context, ext RepoExtension, poolName string) (int, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		INSERT INTO reviewer_pools (pool_name)
+		VALUES ($1)
+		RETURNING id;
+	`
+
+	var id int
+
+	if err := ext.QueryRow(ctx, query, poolName).Scan(&id); err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return 0, apperrors.ErrReviewerPoolAlreadyExists
+		}
+
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *ReviewerPoolRepository) SelectReviewerPoolByName(ctx context.Context, ext RepoExtension, poolName string) (*model.ReviewerPool, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = selectReviewerPoolColumns + `WHERE p.pool_name = $1;`
+
+	pool, err := scanReviewerPool(ext.QueryRow(ctx, query, poolName))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrReviewerPoolNotExist
+		}
+
+		return nil, err
+	}
+
+	return pool, nil
+}
+
+func (r *ReviewerPoolRepository) SelectReviewerPools(ctx context.Context, ext RepoExtension) ([]model.ReviewerPool, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = selectReviewerPoolColumns + `ORDER BY p.pool_name;`
+
+	rows, err := ext.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	pools := make([]model.ReviewerPool, 0, listDefaultCap)
+
+	for rows.Next() {
+		pool, err := scanReviewerPool(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		pools = append(pools, *pool)
+	}
+
+	return pools, rows.Err()
+}
+
+func (r *ReviewerPoolRepository) DeleteReviewerPool(ctx context.Context, ext RepoExtension, poolID int) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		DELETE FROM reviewer_pools
+		WHERE id = $1;
+	`
+
+	tag, err := ext.Exec(ctx, query, poolID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrReviewerPoolNotExist
+	}
+
+	return nil
+}
+
+func (r *ReviewerPoolRepository) InsertPoolMembers(ctx context.Context, ext RepoExtension, poolID int, userIDs []string) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		INSERT INTO reviewer_pool_members (pool_id, user_id)
+		SELECT $1, user_id
+		FROM unnest($2::text[]) AS user_id
+		ON CONFLICT DO NOTHING;
+	`
+
+	_, err := ext.Exec(ctx, query, poolID, userIDs)
+
+	return err
+}
+
+func (r *ReviewerPoolRepository) DeletePoolMembers(ctx context.Context, ext RepoExtension, poolID int, userIDs []string) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		DELETE FROM reviewer_pool_members
+		WHERE pool_id = $1
+		  AND user_id = ANY($2);
+	`
+
+	_, err := ext.Exec(ctx, query, poolID, userIDs)
+
+	return err
+}
+
+func (r *ReviewerPoolRepository) UpsertTeamReviewerPool(ctx context.Context, ext RepoExtension, teamID, poolID int, mode string) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		INSERT INTO team_reviewer_pools (team_id, pool_id, mode)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (team_id, pool_id) DO UPDATE
+		SET mode = EXCLUDED.mode;
+	`
+
+	_, err := ext.Exec(ctx, query, teamID, poolID, mode)
+
+	return err
+}
+
+func (r *ReviewerPoolRepository) DeleteTeamReviewerPool(ctx context.Context, ext RepoExtension, teamID, poolID int) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		DELETE FROM team_reviewer_pools
+		WHERE team_id = $1
+		  AND pool_id = $2;
+	`
+
+	tag, err := ext.Exec(ctx, query, teamID, poolID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return apperrors.ErrReviewerPoolNotAttached
+	}
+
+	return nil
+}
+
+// SelectTeamReviewerPools returns pools in the order they were attached.
+func (r *ReviewerPoolRepository) SelectTeamReviewerPools(ctx context.Context, ext RepoExtension, teamID int) ([]model.TeamReviewerPool, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT p.id,
+		       p.pool_name,
+		       trp.mode,
+		       COALESCE((
+		           SELECT array_agg(m.user_id ORDER BY m.user_id)
+		           FROM reviewer_pool_members m
+		           WHERE m.pool_id = p.id
+		       ), '{}') AS members
+		FROM team_reviewer_pools trp
+		JOIN reviewer_pools p ON p.id = trp.pool_id
+		WHERE trp.team_id = $1
+		ORDER BY trp.created_at, p.id;
+	`
+
+	rows, err := ext.Query(ctx, query, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	pools := make([]model.TeamReviewerPool, 0, listDefaultCap)
+
+	for rows.Next() {
+		var pool model.TeamReviewerPool
+
+		if err := rows.Scan(&pool.PoolID, &pool.PoolName, &pool.Mode, &pool.Members); err != nil {
+			return nil, err
+		}
+
+		pools = append(pools, pool)
+	}
+
+	return pools, rows.Err()
+}
+
+func scanReviewerPool(row pgx.Row) (*model.ReviewerPool, error) {
+	var pool model.ReviewerPool
+
+	if err := row.Scan(
+		&pool.ID,
+		&pool.Name,
+		&pool.Members,
+		&pool.Teams,
+		&pool.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &pool, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
 )
 
 type TeamRepository struct {
@@ -79,6 +80,7 @@ func (r *TeamRepository) InsertTeamLinkWithUser(ctx context.Context, ext RepoExt
 	const query = `
 		INSERT INTO team_lnk (user_id, team_id)
 		VALUES ($1, $2)
+		ON CONFLICT (user_id, team_id) DO NOTHING
 	`
 
 	_, err := ext.Exec(ctx, query, userID, teamID)
@@ -89,54 +91,224 @@ func (r *TeamRepository) InsertTeamLinkWithUser(ctx context.Context, ext RepoExt
 	return nil
 }
 
-func (r *TeamRepository) SelectTeamNameByUserID(ctx context.Context, ext RepoExtension, userID string) (string, error) {
+func (r *TeamRepository) DeleteTeamLinkWithUser(ctx context.Context, ext RepoExtension, teamID int, userID string) error {
 	if ext == nil {
 		ext = r.db
 	}
 
 	const query = `
-		SELECT t.team_name
-		FROM teams t
-		JOIN team_lnk tl ON t.id = tl.team_id
-		WHERE tl.user_id = $1;
+		DELETE FROM team_lnk
+		WHERE user_id = $1 AND team_id = $2;
+	`
+
+	cmd, err := ext.Exec(ctx, query, userID, teamID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrUserNotInTeam
+	}
+
+	return nil
+}
+
+func (r *TeamRepository) SelectTeamByName(ctx context.Context, ext RepoExtension, teamName string) (*model.Team, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT id, team_name, reviewers_count, required_approvals, default_max_open_reviews, review_sla_minutes, stale_review_action
+		FROM teams
+		WHERE team_name = $1;
+	`
+
+	var team model.Team
+
+	if err := ext.QueryRow(ctx, query, teamName).Scan(
+		&team.ID,
+		&team.Name,
+		&team.ReviewersCount,
+		&team.RequiredApprovals,
+		&team.DefaultMaxOpenReviews,
+		&team.ReviewSLAMinutes,
+		&team.StaleReviewAction,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrTeamNotExist
+		}
+
+		return nil, err
+	}
+
+	return &team, nil
+}
+
+func (r *TeamRepository) UpdateTeamReviewersCount(ctx context.Context, ext RepoExtension, teamName string, count int) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		UPDATE teams
+		SET reviewers_count = $1
+		WHERE team_name = $2;
+	`
+
+	cmd, err := ext.Exec(ctx, query, count, teamName)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrTeamNotExist
+	}
+
+	return nil
+}
+
+func (r *TeamRepository) UpdateTeamRequiredApprovals(ctx context.Context, ext RepoExtension, teamName string, count int) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		UPDATE teams
+		SET required_approvals = $1
+		WHERE team_name = $2;
+	`
+
+	cmd, err := ext.Exec(ctx, query, count, teamName)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrTeamNotExist
+	}
+
+	return nil
+}
+
+func (r *TeamRepository) UpdateTeamDefaultMaxOpenReviews(ctx context.Context, ext RepoExtension, teamName string, limit *int) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		UPDATE teams
+		SET default_max_open_reviews = $1
+		WHERE team_name = $2;
+	`
+
+	cmd, err := ext.Exec(ctx, query, limit, teamName)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrTeamNotExist
+	}
+
+	return nil
+}
+
+func (r *TeamRepository) UpdateTeamReviewSLA(ctx context.Context, ext RepoExtension, teamName string, minutes *int, action string) error {
+	if ext == nil {
+		ext = r.db
+	}
 
+	const query = `
+		UPDATE teams
+		SET review_sla_minutes = $1,
+		    stale_review_action = $2
+		WHERE team_name = $3;
 	`
 
-	var teamName string
+	cmd, err := ext.Exec(ctx, query, minutes, action, teamName)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrTeamNotExist
+	}
+
+	return nil
+}
+
+func (r *TeamRepository) SelectTeamByID(ctx context.Context, ext RepoExtension, teamID int) (*model.Team, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT id, team_name, reviewers_count, required_approvals, default_max_open_reviews, review_sla_minutes, stale_review_action
+		FROM teams
+		WHERE id = $1;
+	`
 
-	if err := ext.QueryRow(ctx, query, userID).Scan(&teamName); err != nil {
+	var team model.Team
+
+	if err := ext.QueryRow(ctx, query, teamID).Scan(
+		&team.ID,
+		&team.Name,
+		&team.ReviewersCount,
+		&team.RequiredApprovals,
+		&team.DefaultMaxOpenReviews,
+		&team.ReviewSLAMinutes,
+		&team.StaleReviewAction,
+	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return "", apperrors.ErrTeamNotExist
+			return nil, apperrors.ErrTeamNotExist
 		}
 
-		return "", err
+		return nil, err
 	}
 
-	return teamName, nil
+	return &team, nil
 }
 
-func (r *TeamRepository) SelectTeamIDByUserID(ctx context.Context, ext RepoExtension, userID string) (int, error) {
+func (r *TeamRepository) SelectTeamsByUserID(ctx context.Context, ext RepoExtension, userID string) ([]model.Team, error) {
 	if ext == nil {
 		ext = r.db
 	}
 
 	const query = `
-		SELECT t.id
+		SELECT t.id, t.team_name, t.reviewers_count, t.required_approvals, t.default_max_open_reviews, t.review_sla_minutes, t.stale_review_action
 		FROM teams t
 		JOIN team_lnk tl ON t.id = tl.team_id
-		WHERE tl.user_id = $1;
-
+		WHERE tl.user_id = $1
+		ORDER BY t.id;
 	`
 
-	var teamID int
+	rows, err := ext.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := ext.QueryRow(ctx, query, userID).Scan(&teamID); err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return 0, apperrors.ErrTeamNotExist
+	defer rows.Close()
+
+	teams := make([]model.Team, 0, listDefaultCap)
+
+	for rows.Next() {
+		var team model.Team
+
+		if err := rows.Scan(
+			&team.ID,
+			&team.Name,
+			&team.ReviewersCount,
+			&team.RequiredApprovals,
+			&team.DefaultMaxOpenReviews,
+			&team.ReviewSLAMinutes,
+			&team.StaleReviewAction,
+		); err != nil {
+			return nil, err
 		}
 
-		return 0, err
+		teams = append(teams, team)
 	}
 
-	return teamID, nil
+	return teams, rows.Err()
 }
@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+)
+
+type UnavailabilityRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewUnavailabilityRepository(db *pgxpool.Pool) *UnavailabilityRepository {
+	return &UnavailabilityRepository{db: db}
+}
+
+func (r *UnavailabilityRepository) Pool() *pgxpool.Pool {
+	return r.db
+}
+
+func (r *UnavailabilityRepository) InsertUnavailability(
+	ctx context.Context,
+	ext RepoExtension,
+	userID string,
+	startsAt, endsAt time.Time,
+	reason string,
+) (*model.Unavailability, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, user_id, starts_at, ends_at, reason, reassigned_at, created_at;
+	`
+
+	return scanUnavailability(ext.QueryRow(ctx, query, userID, startsAt, endsAt, reason))
+}
+
+func (r *UnavailabilityRepository) SelectUnavailability(
+	ctx context.Context,
+	ext RepoExtension,
+	userID string,
+	includePast bool,
+) ([]model.Unavailability, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT id, user_id, starts_at, ends_at, reason, reassigned_at, created_at
+		FROM user_unavailability
+		WHERE ($1 = '' OR user_id = $1)
+		  AND ($2 OR ends_at > now())
+		ORDER BY starts_at, id;
+	`
+
+	rows, err := ext.Query(ctx, query, userID, includePast)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return scanUnavailabilities(rows)
+}
+
+func (r *UnavailabilityRepository) DeleteUnavailability(ctx context.Context, ext RepoExtension, id int64) (*model.Unavailability, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		DELETE FROM user_unavailability
+		WHERE id = $1
+		RETURNING id, user_id, starts_at, ends_at, reason, reassigned_at, created_at;
+	`
+
+	return scanUnavailability(ext.QueryRow(ctx, query, id))
+}
+
+func (r *UnavailabilityRepository) ClaimStartedUnavailability(ctx context.Context, ext RepoExtension, limit int) ([]model.Unavailability, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT id, user_id, starts_at, ends_at, reason, reassigned_at, created_at
+		FROM user_unavailability
+		WHERE reassigned_at IS NULL
+		  AND starts_at <= now()
+		  AND ends_at > now()
+		ORDER BY starts_at, id
+		LIMIT $1
+		FOR UPDATE SKIP LOCKED;
+	`
+
+	rows, err := ext.Query(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	return scanUnavailabilities(rows)
+}
+
+func (r *UnavailabilityRepository) MarkReassigned(ctx context.Context, ext RepoExtension, id int64) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		UPDATE user_unavailability
+		SET reassigned_at = now()
+		WHERE id = $1;
+	`
+
+	cmd, err := ext.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrUnavailabilityNotExist
+	}
+
+	return nil
+}
+
+func scanUnavailability(row pgx.Row) (*model.Unavailability, error) {
+	var u model.Unavailability
+
+	if err := row.Scan(
+		&u.ID,
+		&u.UserID,
+		&u.StartsAt,
+		&u.EndsAt,
+		&u.Reason,
+		&u.ReassignedAt,
+		&u.CreatedAt,
+	); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrUnavailabilityNotExist
+		}
+
+		return nil, err
+	}
+
+	return &u, nil
+}
+
+func scanUnavailabilities(rows pgx.Rows) ([]model.Unavailability, error) {
+	periods := make([]model.Unavailability, 0, listDefaultCap)
+
+	for rows.Next() {
+		u, err := scanUnavailability(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		periods = append(periods, *u)
+	}
+
+	return periods, rows.Err()
+}
@@ -49,13 +49,38 @@ func (r *UserRepository) UpsertUser(
 	return nil
 }
 
+func (r *UserRepository) InsertUserIfAbsent(
+	ctx context.Context,
+	ext RepoExtension,
+	userID string,
+	username string,
+	isActive bool,
+) (bool, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		INSERT INTO users (id, username, is_active)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (id) DO NOTHING;
+	`
+
+	cmd, err := ext.Exec(ctx, query, userID, username, isActive)
+	if err != nil {
+		return false, err
+	}
+
+	return cmd.RowsAffected() == 1, nil
+}
+
 func (r *UserRepository) SelectUsersByTeamID(ctx context.Context, ext RepoExtension, teamID int) ([]model.User, error) {
 	if ext == nil {
 		ext = r.db
 	}
 
 	const query = `
-		SELECT u.id, u.username, u.is_active, u.created_at, u.updated_at
+		SELECT u.id, u.username, u.is_active, u.max_open_reviews, u.created_at, u.updated_at
 		FROM users u 
 		JOIN team_lnk l ON u.id = l.user_id
 		WHERE l.team_id = $1;
@@ -73,7 +98,7 @@ func (r *UserRepository) SelectUsersByTeamID(ctx context.Context, ext RepoExtens
 	for rows.Next() {
 		var user model.User
 
-		if err := rows.Scan(&user.ID, &user.Username, &user.IsActive, &user.CreatedAt, &user.UpdatedAt); err != nil {
+		if err := rows.Scan(&user.ID, &user.Username, &user.IsActive, &user.MaxOpenReviews, &user.CreatedAt, &user.UpdatedAt); err != nil {
 			return nil, err
 		}
 
@@ -89,14 +114,21 @@ func (r *UserRepository) SelectUserByID(ctx context.Context, ext RepoExtension,
 	}
 
 	const query = `
-		SELECT u.id, u.username, u.is_active, u.created_at, u.updated_at 
+		SELECT u.id, u.username, u.is_active, u.max_open_reviews, u.created_at, u.updated_at 
 		FROM users u
 		WHERE u.id = $1;
 	`
 
 	var user model.User
 
-	if err := ext.QueryRow(ctx, query, userID).Scan(&user.ID, &user.Username, &user.IsActive, &user.CreatedAt, &user.UpdatedAt); err != nil {
+	if err := ext.QueryRow(ctx, query, userID).Scan(
+		&user.ID,
+		&user.Username,
+		&user.IsActive,
+		&user.MaxOpenReviews,
+		&user.CreatedAt,
+		&user.UpdatedAt,
+	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, apperrors.ErrUserNotExist
 		}
@@ -129,3 +161,86 @@ func (r *UserRepository) UpdateUserActive(ctx context.Context, ext RepoExtension
 
 	return nil
 }
+
+func (r *UserRepository) UpdateUsersActive(ctx context.Context, ext RepoExtension, userIDs []string, isActive bool) ([]string, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		UPDATE users
+		SET is_active = $1
+		WHERE id = ANY($2)
+		RETURNING id;
+	`
+
+	rows, err := ext.Query(ctx, query, isActive, userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	ids := make([]string, 0, len(userIDs))
+
+	for rows.Next() {
+		var id string
+
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
+
+func (r *UserRepository) UpdateUserMaxOpenReviews(ctx context.Context, ext RepoExtension, userID string, limit *int) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		UPDATE users
+		SET max_open_reviews = $1,
+		    updated_at = now()
+		WHERE id = $2;
+	`
+
+	cmd, err := ext.Exec(ctx, query, limit, userID)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrUserNotExist
+	}
+
+	return nil
+}
+
+// SelectReviewLoad falls back to the strictest team default; a nil capacity means unlimited.
+func (r *UserRepository) SelectReviewLoad(ctx context.Context, ext RepoExtension, userID string) (*model.ReviewLoad, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT rl.open_reviews, rl.max_open_reviews
+		FROM reviewer_load rl
+		WHERE rl.user_id = $1;
+	`
+
+	var load model.ReviewLoad
+
+	if err := ext.QueryRow(ctx, query, userID).Scan(&load.OpenReviews, &load.MaxOpenReviews); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, apperrors.ErrUserNotExist
+		}
+
+		return nil, err
+	}
+
+	return &load, nil
+}
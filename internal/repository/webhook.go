@@ -0,0 +1,342 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+)
+
+type WebhookRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookRepository(db *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+func (r *WebhookRepository) Pool() *pgxpool.Pool {
+	return r.db
+}
+
+func (r *WebhookRepository) InsertSubscription(
+	ctx context.Context,
+	ext RepoExtension,
+	url, secret string,
+	events []string,
+) (*model.WebhookSubscription, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		INSERT INTO webhook_subscriptions (url, secret, events)
+		VALUES ($1, $2, $3)
+		RETURNING id, url, secret, events, is_active, created_at;
+	`
+
+	var sub model.WebhookSubscription
+
+	if err := ext.QueryRow(ctx, query, url, secret, events).Scan(
+		&sub.ID,
+		&sub.URL,
+		&sub.Secret,
+		&sub.Events,
+		&sub.IsActive,
+		&sub.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &sub, nil
+}
+
+func (r *WebhookRepository) SelectSubscriptions(ctx context.Context, ext RepoExtension) ([]model.WebhookSubscription, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT id, url, events, is_active, created_at
+		FROM webhook_subscriptions
+		ORDER BY id;
+	`
+
+	rows, err := ext.Query(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	subs := make([]model.WebhookSubscription, 0, listDefaultCap)
+
+	for rows.Next() {
+		var sub model.WebhookSubscription
+
+		if err := rows.Scan(&sub.ID, &sub.URL, &sub.Events, &sub.IsActive, &sub.CreatedAt); err != nil {
+			return nil, err
+		}
+
+		subs = append(subs, sub)
+	}
+
+	return subs, rows.Err()
+}
+
+func (r *WebhookRepository) DeleteSubscription(ctx context.Context, ext RepoExtension, id int64) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		DELETE FROM webhook_subscriptions
+		WHERE id = $1;
+	`
+
+	cmd, err := ext.Exec(ctx, query, id)
+	if err != nil {
+		return err
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return apperrors.ErrWebhookNotExist
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) InsertOutboxEvent(ctx context.Context, ext RepoExtension, eventType string, payload []byte) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		INSERT INTO outbox_events (event_type, payload)
+		VALUES ($1, $2);
+	`
+
+	_, err := ext.Exec(ctx, query, eventType, string(payload))
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) FanOutOutboxEvents(ctx context.Context, ext RepoExtension, limit int) (int64, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		WITH events AS (
+		    SELECT id, event_type
+		    FROM outbox_events
+		    WHERE processed_at IS NULL
+		    ORDER BY id
+		    LIMIT $1
+		    FOR UPDATE SKIP LOCKED
+		),
+		deliveries AS (
+		    INSERT INTO webhook_deliveries (outbox_event_id, subscription_id)
+		    SELECT e.id, s.id
+		    FROM events e
+		    JOIN webhook_subscriptions s
+		      ON s.is_active
+		     AND (cardinality(s.events) = 0 OR e.event_type = ANY(s.events))
+		    ON CONFLICT (outbox_event_id, subscription_id) DO NOTHING
+		)
+		UPDATE outbox_events o
+		SET processed_at = now()
+		FROM events e
+		WHERE o.id = e.id;
+	`
+
+	cmd, err := ext.Exec(ctx, query, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	return cmd.RowsAffected(), nil
+}
+
+func (r *WebhookRepository) ClaimDueDeliveries(
+	ctx context.Context,
+	ext RepoExtension,
+	limit int,
+	lease time.Duration,
+) ([]model.WebhookDelivery, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		WITH due AS (
+		    SELECT id
+		    FROM webhook_deliveries
+		    WHERE status = 'PENDING'
+		      AND next_attempt_at <= now()
+		    ORDER BY next_attempt_at, id
+		    LIMIT $1
+		    FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = now() + $2::interval
+		FROM due, outbox_events o, webhook_subscriptions s
+		WHERE d.id = due.id
+		  AND o.id = d.outbox_event_id
+		  AND s.id = d.subscription_id
+		RETURNING d.id, d.subscription_id, o.id, o.event_type, o.payload, d.status::text, d.attempts,
+		          o.created_at, d.created_at, s.url, s.secret;
+	`
+
+	rows, err := ext.Query(ctx, query, limit, lease)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	deliveries := make([]model.WebhookDelivery, 0, limit)
+
+	for rows.Next() {
+		var d model.WebhookDelivery
+
+		if err := rows.Scan(
+			&d.ID,
+			&d.SubscriptionID,
+			&d.EventID,
+			&d.EventType,
+			&d.Payload,
+			&d.Status,
+			&d.Attempts,
+			&d.EventCreatedAt,
+			&d.CreatedAt,
+			&d.URL,
+			&d.Secret,
+		); err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (r *WebhookRepository) UpdateDeliveryAttempt(ctx context.Context, ext RepoExtension, result *model.WebhookDeliveryResult) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		UPDATE webhook_deliveries
+		SET status = $2,
+		    attempts = $3,
+		    last_status_code = $4,
+		    last_error = $5,
+		    next_attempt_at = $6,
+		    delivered_at = CASE WHEN $2 = 'DELIVERED' THEN now() ELSE delivered_at END
+		WHERE id = $1;
+	`
+
+	_, err := ext.Exec(ctx, query,
+		result.ID,
+		result.Status,
+		result.Attempts,
+		result.StatusCode,
+		result.Error,
+		result.NextAttemptAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) SelectDeadDeliveries(
+	ctx context.Context,
+	ext RepoExtension,
+	subscriptionID int64,
+	limit int,
+) ([]model.WebhookDelivery, error) {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		SELECT d.id, d.subscription_id, o.id, o.event_type, o.payload, d.status::text, d.attempts,
+		       d.last_status_code, d.last_error, o.created_at, d.created_at
+		FROM webhook_deliveries d
+		JOIN outbox_events o ON o.id = d.outbox_event_id
+		WHERE d.status = 'DEAD'
+		  AND ($1 = 0 OR d.subscription_id = $1)
+		ORDER BY d.id DESC
+		LIMIT $2;
+	`
+
+	rows, err := ext.Query(ctx, query, subscriptionID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	deliveries := make([]model.WebhookDelivery, 0, limit)
+
+	for rows.Next() {
+		var d model.WebhookDelivery
+
+		if err := rows.Scan(
+			&d.ID,
+			&d.SubscriptionID,
+			&d.EventID,
+			&d.EventType,
+			&d.Payload,
+			&d.Status,
+			&d.Attempts,
+			&d.LastStatusCode,
+			&d.LastError,
+			&d.EventCreatedAt,
+			&d.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		deliveries = append(deliveries, d)
+	}
+
+	return deliveries, rows.Err()
+}
+
+func (r *WebhookRepository) RequeueDeadDelivery(ctx context.Context, ext RepoExtension, id int64) error {
+	if ext == nil {
+		ext = r.db
+	}
+
+	const query = `
+		UPDATE webhook_deliveries
+		SET status = 'PENDING', attempts = 0, next_attempt_at = now(), last_error = ''
+		WHERE id = $1 AND status = 'DEAD'
+		RETURNING id;
+	`
+
+	var updated int64
+
+	if err := ext.QueryRow(ctx, query, id).Scan(&updated); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return apperrors.ErrWebhookDeliveryNotExist
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"avito-test-assignment/internal/actor"
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+	"avito-test-assignment/internal/repository"
+)
+
+const (
+	reassignReasonManual      = "manual"
+	reassignReasonDeactivated = "user_deactivated"
+	reassignReasonTeamRemoved = "removed_from_team"
+	reassignReasonTeamMoved   = "moved_to_another_team"
+	reassignReasonUnavailable = "user_unavailable"
+	reassignReasonStale       = "review_sla_exceeded"
+)
+
+type AuditRepositoryForWrite interface {
+	InsertAuditEvent(ctx context.Context, ext repository.RepoExtension, event *model.AuditEvent) error
+}
+
+type AuditRepositoryForAudit interface {
+	SelectAuditEvents(ctx context.Context, ext repository.RepoExtension, filter *model.AuditFilter) ([]model.AuditEvent, error)
+}
+
+type auditRecord struct {
+	action        string
+	pullRequestID string
+	userID        string
+	teamName      string
+	before        any
+	after         any
+}
+
+type auditWriter struct {
+	repo AuditRepositoryForWrite
+}
+
+func (w auditWriter) record(ctx context.Context, ext repository.RepoExtension, rec auditRecord) error {
+	event := &model.AuditEvent{
+		Actor:         actor.FromContext(ctx),
+		Action:        rec.action,
+		PullRequestID: rec.pullRequestID,
+		UserID:        rec.userID,
+		TeamName:      rec.teamName,
+	}
+
+	var err error
+
+	if event.Before, err = marshalAuditState(rec.before); err != nil {
+		return err
+	}
+
+	if event.After, err = marshalAuditState(rec.after); err != nil {
+		return err
+	}
+
+	if err = w.repo.InsertAuditEvent(ctx, ext, event); err != nil {
+		return fmt.Errorf("failed to write audit event %s: %w", rec.action, err)
+	}
+
+	return nil
+}
+
+func marshalAuditState(state any) (json.RawMessage, error) {
+	if state == nil {
+		return nil, nil
+	}
+
+	raw, err := json.Marshal(state)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal audit state: %w", err)
+	}
+
+	return raw, nil
+}
+
+type AuditService struct {
+	auditRepo AuditRepositoryForAudit
+}
+
+func NewAuditService(auditRepo AuditRepositoryForAudit) *AuditService {
+	return &AuditService{
+		auditRepo: auditRepo,
+	}
+}
+
+func (s *AuditService) List(ctx context.Context, qp *model.AuditQueryParam) (*model.AuditListResponse, error) {
+	limit := qp.Limit
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+
+	filter := &model.AuditFilter{
+		PullRequestID: qp.PullRequestID,
+		UserID:        qp.UserID,
+		TeamName:      qp.TeamName,
+		Actor:         qp.Actor,
+		Action:        qp.Action,
+		From:          qp.From,
+		To:            qp.To,
+		Limit:         limit + 1,
+	}
+
+	if qp.Cursor != "" {
+		afterTime, afterID, err := decodeCursor(qp.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		id, err := strconv.ParseInt(afterID, 10, 64)
+		if err != nil {
+			return nil, apperrors.ErrInvalidCursor
+		}
+
+		filter.AfterTime = &afterTime
+		filter.AfterID = id
+	}
+
+	events, err := s.auditRepo.SelectAuditEvents(ctx, nil, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select audit events: %w", err)
+	}
+
+	response := &model.AuditListResponse{
+		Events: events,
+	}
+
+	if len(events) > limit {
+		response.Events = events[:limit]
+
+		last := response.Events[limit-1]
+		response.NextCursor = encodeCursor(last.CreatedAt, strconv.FormatInt(last.ID, 10))
+	}
+
+	return response, nil
+}
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"avito-test-assignment/internal/actor"
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+	"avito-test-assignment/internal/repository"
+)
+
+const (
+	apiTokenPrefix = "rvw_"
+	apiTokenBytes  = 32
+
+	AdminActorID = "admin"
+)
+
+type AuthRepositoryForAuth interface {
+	Pool() *pgxpool.Pool
+
+	SelectActorByTokenHash(ctx context.Context, ext repository.RepoExtension, tokenHash string) (*actor.Actor, error)
+	InsertAPIToken(ctx context.Context, ext repository.RepoExtension, userID, name, tokenHash string) (*model.APIToken, error)
+	SelectAPITokens(ctx context.Context, ext repository.RepoExtension, userID string) ([]model.APIToken, error)
+	RevokeAPIToken(ctx context.Context, ext repository.RepoExtension, id int64) (*model.APIToken, error)
+	SelectUserRole(ctx context.Context, ext repository.RepoExtension, userID string) (string, error)
+	SelectUserIsActive(ctx context.Context, ext repository.RepoExtension, userID string) (bool, error)
+	UpdateUserRole(ctx context.Context, ext repository.RepoExtension, userID, role string) error
+	SelectUserTeamNames(ctx context.Context, ext repository.RepoExtension, userID string) ([]string, error)
+	SelectCodeOwnerRuleTeamName(ctx context.Context, ext repository.RepoExtension, ruleID int64) (string, error)
+	SelectUnavailabilityUserID(ctx context.Context, ext repository.RepoExtension, id int64) (string, error)
+	SelectPullRequestAuthorID(ctx context.Context, ext repository.RepoExtension, pullRequestID string) (string, error)
+}
+
+type AuthService struct {
+	authRepo    AuthRepositoryForAuth
+	audit       auditWriter
+	adminTokens [][]byte
+}
+
+func NewAuthService(authRepo AuthRepositoryForAuth, auditRepo AuditRepositoryForWrite, adminTokens []string) *AuthService {
+	hashes := make([][]byte, 0, len(adminTokens))
+
+	for _, token := range adminTokens {
+		if token != "" {
+			hash := sha256.Sum256([]byte(token))
+			hashes = append(hashes, hash[:])
+		}
+	}
+
+	return &AuthService{
+		authRepo:    authRepo,
+		audit:       auditWriter{repo: auditRepo},
+		adminTokens: hashes,
+	}
+}
+
+// Authenticate prefers static admin tokens; user tokens are stored only as SHA-256 hashes.
+func (s *AuthService) Authenticate(ctx context.Context, token string) (*actor.Actor, error) {
+	if token == "" {
+		return nil, apperrors.ErrUnauthorized
+	}
+
+	hash := sha256.Sum256([]byte(token))
+
+	for _, admin := range s.adminTokens {
+		if subtle.ConstantTimeCompare(hash[:], admin) == 1 {
+			return &actor.Actor{ID: AdminActorID, Role: actor.RoleAdmin}, nil
+		}
+	}
+
+	a, err := s.authRepo.SelectActorByTokenHash(ctx, nil, hex.EncodeToString(hash[:]))
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUnauthorized) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("failed to select actor by token: %w", err)
+	}
+
+	return a, nil
+}
+
+// CreateToken returns the token only once.
+func (s *AuthService) CreateToken(ctx context.Context, userID, name string) (response *model.CreateAPITokenResponse, err error) {
+	raw := make([]byte, apiTokenBytes)
+	if _, err = rand.Read(raw); err != nil {
+		return nil, fmt.Errorf("failed to generate token: %w", err)
+	}
+
+	token := apiTokenPrefix + base64.RawURLEncoding.EncodeToString(raw)
+	hash := sha256.Sum256([]byte(token))
+
+	tx, err := s.authRepo.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	if _, err = s.authRepo.SelectUserRole(ctx, tx, userID); err != nil {
+		return nil, fmt.Errorf("failed to select user role: %w", err)
+	}
+
+	apiToken, err := s.authRepo.InsertAPIToken(ctx, tx, userID, name, hex.EncodeToString(hash[:]))
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert api token: %w", err)
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action: model.AuditAPITokenCreated,
+		userID: userID,
+		after:  apiToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &model.CreateAPITokenResponse{
+		Token:    token,
+		APIToken: *apiToken,
+	}, nil
+}
+
+func (s *AuthService) ListTokens(ctx context.Context, userID string) (*model.APITokenListResponse, error) {
+	tokens, err := s.authRepo.SelectAPITokens(ctx, nil, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select api tokens: %w", err)
+	}
+
+	return &model.APITokenListResponse{Tokens: tokens}, nil
+}
+
+func (s *AuthService) RevokeToken(ctx context.Context, id int64) (err error) {
+	tx, err := s.authRepo.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	token, err := s.authRepo.RevokeAPIToken(ctx, tx, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api token: %w", err)
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action: model.AuditAPITokenRevoked,
+		userID: token.UserID,
+		after:  token,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (s *AuthService) SetRole(ctx context.Context, userID, role string) (response *model.UserRoleResponse, err error) {
+	tx, err := s.authRepo.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	prev, err := s.authRepo.SelectUserRole(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select user role: %w", err)
+	}
+
+	if err = s.authRepo.UpdateUserRole(ctx, tx, userID, role); err != nil {
+		return nil, fmt.Errorf("failed to update user role: %w", err)
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action: model.AuditUserRoleChanged,
+		userID: userID,
+		before: map[string]any{"role": prev},
+		after:  map[string]any{"role": role},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &model.UserRoleResponse{UserID: userID, Role: role}, nil
+}
+
+func (s *AuthService) CanManageUser(ctx context.Context, a *actor.Actor, userID string) (bool, error) {
+	if a.IsAdmin() {
+		return true, nil
+	}
+
+	if a.Role != actor.RoleTeamLead {
+		return false, nil
+	}
+
+	teams, err := s.authRepo.SelectUserTeamNames(ctx, nil, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to select user teams: %w", err)
+	}
+
+	return a.CanManageAnyTeam(teams), nil
+}
+
+// CanChangeMembersActivity requires CanManageUser for every existing user whose is_active would change.
+func (s *AuthService) CanChangeMembersActivity(ctx context.Context, a *actor.Actor, members []model.UserRequest) (bool, error) {
+	if a.IsAdmin() {
+		return true, nil
+	}
+
+	for _, member := range members {
+		isActive, err := s.authRepo.SelectUserIsActive(ctx, nil, member.UserID)
+		if errors.Is(err, apperrors.ErrUserNotExist) {
+			continue
+		}
+
+		if err != nil {
+			return false, fmt.Errorf("failed to select user activity: %w", err)
+		}
+
+		if isActive == member.IsActive {
+			continue
+		}
+
+		allowed, err := s.CanManageUser(ctx, a, member.UserID)
+		if err != nil || !allowed {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func (s *AuthService) CanManageCodeOwnerRule(ctx context.Context, a *actor.Actor, ruleID int64) (bool, error) {
+	if a.IsAdmin() {
+		return true, nil
+	}
+
+	teamName, err := s.authRepo.SelectCodeOwnerRuleTeamName(ctx, nil, ruleID)
+	if err != nil {
+		return false, fmt.Errorf("failed to select code owner rule team: %w", err)
+	}
+
+	return a.CanManageTeam(teamName), nil
+}
+
+// CanManageUnavailability always allows actors to remove their own periods.
+func (s *AuthService) CanManageUnavailability(ctx context.Context, a *actor.Actor, id int64) (bool, error) {
+	if a.IsAdmin() {
+		return true, nil
+	}
+
+	userID, err := s.authRepo.SelectUnavailabilityUserID(ctx, nil, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to select unavailability user: %w", err)
+	}
+
+	if userID == a.ID {
+		return true, nil
+	}
+
+	return s.CanManageUser(ctx, a, userID)
+}
+
+// CanManagePullRequest allows the author, a lead of one of the author's teams or an admin.
+func (s *AuthService) CanManagePullRequest(ctx context.Context, a *actor.Actor, pullRequestID string) (bool, error) {
+	if a.IsAdmin() {
+		return true, nil
+	}
+
+	authorID, err := s.authRepo.SelectPullRequestAuthorID(ctx, nil, pullRequestID)
+	if err != nil {
+		return false, fmt.Errorf("failed to select pull request author: %w", err)
+	}
+
+	if authorID == a.ID {
+		return true, nil
+	}
+
+	return s.CanManageUser(ctx, a, authorID)
+}
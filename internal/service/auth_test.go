@@ -0,0 +1,266 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"avito-test-assignment/internal/actor"
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/jwt"
+	"avito-test-assignment/internal/model"
+	"avito-test-assignment/internal/repository"
+)
+
+type fakeAuthRepo struct {
+	AuthRepositoryForAuth
+
+	actors    map[string]*actor.Actor
+	userRoles map[string]string
+	userTeams map[string][]string
+	active    map[string]bool
+	prAuthors map[string]string
+}
+
+func (r *fakeAuthRepo) SelectPullRequestAuthorID(_ context.Context, _ repository.RepoExtension, pullRequestID string) (string, error) {
+	authorID, ok := r.prAuthors[pullRequestID]
+	if !ok {
+		return "", apperrors.ErrPullRequestNotExist
+	}
+
+	return authorID, nil
+}
+
+func (r *fakeAuthRepo) SelectUserIsActive(_ context.Context, _ repository.RepoExtension, userID string) (bool, error) {
+	isActive, ok := r.active[userID]
+	if !ok {
+		return false, apperrors.ErrUserNotExist
+	}
+
+	return isActive, nil
+}
+
+func (r *fakeAuthRepo) SelectUserRole(_ context.Context, _ repository.RepoExtension, userID string) (string, error) {
+	role, ok := r.userRoles[userID]
+	if !ok {
+		return "", apperrors.ErrUserNotExist
+	}
+
+	return role, nil
+}
+
+func (r *fakeAuthRepo) SelectActorByTokenHash(_ context.Context, _ repository.RepoExtension, tokenHash string) (*actor.Actor, error) {
+	a, ok := r.actors[tokenHash]
+	if !ok {
+		return nil, apperrors.ErrUnauthorized
+	}
+
+	return a, nil
+}
+
+func (r *fakeAuthRepo) SelectUserTeamNames(_ context.Context, _ repository.RepoExtension, userID string) ([]string, error) {
+	return r.userTeams[userID], nil
+}
+
+func tokenHash(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
+}
+
+func TestAuthenticate(t *testing.T) {
+	lead := &actor.Actor{ID: "u1", Role: actor.RoleTeamLead, Teams: []string{"backend"}}
+	repo := &fakeAuthRepo{actors: map[string]*actor.Actor{tokenHash("rvw_lead"): lead}}
+	svc := NewAuthService(repo, nil, []string{"", "static-admin"})
+
+	a, err := svc.Authenticate(context.Background(), "static-admin")
+	if err != nil || a.ID != AdminActorID || !a.IsAdmin() {
+		t.Fatalf("admin token resolved to %+v, %v", a, err)
+	}
+
+	a, err = svc.Authenticate(context.Background(), "rvw_lead")
+	if err != nil || a != lead {
+		t.Fatalf("user token resolved to %+v, %v", a, err)
+	}
+
+	for _, token := range []string{"", "rvw_unknown"} {
+		if _, err := svc.Authenticate(context.Background(), token); !errors.Is(err, apperrors.ErrUnauthorized) {
+			t.Errorf("Authenticate(%q) = %v, want ErrUnauthorized", token, err)
+		}
+	}
+}
+
+func TestCanManageUser(t *testing.T) {
+	repo := &fakeAuthRepo{userTeams: map[string][]string{"u2": {"backend"}, "u3": {"frontend"}}}
+	svc := NewAuthService(repo, nil, nil)
+
+	tests := []struct {
+		name   string
+		actor  *actor.Actor
+		userID string
+		want   bool
+	}{
+		{"admin manages anyone", &actor.Actor{Role: actor.RoleAdmin}, "u3", true},
+		{"lead manages own team", &actor.Actor{Role: actor.RoleTeamLead, Teams: []string{"backend"}}, "u2", true},
+		{"lead does not manage other team", &actor.Actor{Role: actor.RoleTeamLead, Teams: []string{"backend"}}, "u3", false},
+		{"member manages nobody", &actor.Actor{Role: actor.RoleMember, Teams: []string{"backend"}}, "u2", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.CanManageUser(context.Background(), tt.actor, tt.userID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("CanManageUser = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanChangeMembersActivity(t *testing.T) {
+	repo := &fakeAuthRepo{
+		userTeams: map[string][]string{"u2": {"backend"}, "u3": {"frontend"}},
+		active:    map[string]bool{"u2": true, "u3": true},
+	}
+	svc := NewAuthService(repo, nil, nil)
+	lead := &actor.Actor{Role: actor.RoleTeamLead, Teams: []string{"backend"}}
+
+	tests := []struct {
+		name    string
+		actor   *actor.Actor
+		members []model.UserRequest
+		want    bool
+	}{
+		{"new user", lead, []model.UserRequest{{UserID: "u9", IsActive: false}}, true},
+		{"unchanged user of other team", lead, []model.UserRequest{{UserID: "u3", IsActive: true}}, true},
+		{"deactivates own team member", lead, []model.UserRequest{{UserID: "u2", IsActive: false}}, true},
+		{"deactivates user of other team", lead, []model.UserRequest{{UserID: "u2", IsActive: true}, {UserID: "u3", IsActive: false}}, false},
+		{"admin deactivates anyone", &actor.Actor{Role: actor.RoleAdmin}, []model.UserRequest{{UserID: "u3", IsActive: false}}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.CanChangeMembersActivity(context.Background(), tt.actor, tt.members)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("CanChangeMembersActivity = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+type fakeJWTVerifier struct {
+	claims jwt.Claims
+	err    error
+}
+
+func (v fakeJWTVerifier) Verify(string) (jwt.Claims, error) {
+	return v.claims, v.err
+}
+
+func TestJWTAuthenticate(t *testing.T) {
+	repo := &fakeAuthRepo{
+		userRoles: map[string]string{"u1": actor.RoleMember, "u2": actor.RoleTeamLead},
+		userTeams: map[string][]string{"u1": {"backend"}, "u2": {"frontend"}},
+	}
+
+	tests := []struct {
+		name     string
+		verifier fakeJWTVerifier
+		wantID   string
+		wantRole string
+		wantErr  error
+	}{
+		{
+			name:     "role from claim",
+			verifier: fakeJWTVerifier{claims: jwt.Claims{"email": "u1", "groups": []any{"staff", actor.RoleTeamLead, actor.RoleAdmin}}},
+			wantID:   "u1",
+			wantRole: actor.RoleAdmin,
+		},
+		{
+			name:     "stored role without claim",
+			verifier: fakeJWTVerifier{claims: jwt.Claims{"email": "u2", "groups": []any{"staff"}}},
+			wantID:   "u2",
+			wantRole: actor.RoleTeamLead,
+		},
+		{
+			name:     "unknown user",
+			verifier: fakeJWTVerifier{claims: jwt.Claims{"email": "u9"}},
+			wantErr:  apperrors.ErrUnauthorized,
+		},
+		{
+			name:     "missing user claim",
+			verifier: fakeJWTVerifier{claims: jwt.Claims{"sub": "u1"}},
+			wantErr:  apperrors.ErrUnauthorized,
+		},
+		{
+			name:     "invalid token",
+			verifier: fakeJWTVerifier{err: jwt.ErrExpired},
+			wantErr:  apperrors.ErrUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, err := NewJWTAuthenticator(tt.verifier, repo, "email", "groups").Authenticate(context.Background(), "token")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Authenticate() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			if a.ID != tt.wantID || a.Role != tt.wantRole || len(a.Teams) != 1 {
+				t.Fatalf("unexpected actor %+v", a)
+			}
+		})
+	}
+}
+
+func TestCanManagePullRequest(t *testing.T) {
+	repo := &fakeAuthRepo{
+		userTeams: map[string][]string{"u2": {"backend"}, "u3": {"frontend"}},
+		prAuthors: map[string]string{"pr-1": "u2", "pr-2": "u3"},
+	}
+	svc := NewAuthService(repo, nil, nil)
+	lead := &actor.Actor{ID: "u1", Role: actor.RoleTeamLead, Teams: []string{"backend"}}
+
+	tests := []struct {
+		name  string
+		actor *actor.Actor
+		prID  string
+		want  bool
+	}{
+		{"author", &actor.Actor{ID: "u2", Role: actor.RoleMember}, "pr-1", true},
+		{"another member", &actor.Actor{ID: "u4", Role: actor.RoleMember, Teams: []string{"backend"}}, "pr-1", false},
+		{"lead of author's team", lead, "pr-1", true},
+		{"lead of another team", lead, "pr-2", false},
+		{"admin", &actor.Actor{Role: actor.RoleAdmin}, "pr-2", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := svc.CanManagePullRequest(context.Background(), tt.actor, tt.prID)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("CanManagePullRequest = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := svc.CanManagePullRequest(context.Background(), lead, "pr-9"); !errors.Is(err, apperrors.ErrPullRequestNotExist) {
+		t.Fatalf("missing pull request: got %v, want %v", err, apperrors.ErrPullRequestNotExist)
+	}
+}
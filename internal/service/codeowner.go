@@ -0,0 +1,378 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+	"avito-test-assignment/internal/repository"
+)
+
+// MatchCodeOwnerPattern follows CODEOWNERS: a leading "/" anchors to the root, a pattern without other "/"
+// matches at any depth, "**" spans directories and a directory pattern covers everything below it.
+func MatchCodeOwnerPattern(pattern, filePath string) bool {
+	anchored := strings.HasPrefix(pattern, "/")
+
+	pattern = strings.Trim(pattern, "/")
+	filePath = strings.Trim(filePath, "/")
+
+	if pattern == "" || filePath == "" {
+		return false
+	}
+
+	patSegs := strings.Split(pattern, "/")
+	if !anchored && len(patSegs) == 1 {
+		patSegs = append([]string{"**"}, patSegs...)
+	}
+
+	pathSegs := strings.Split(filePath, "/")
+
+	if matchSegments(patSegs, pathSegs) {
+		return true
+	}
+
+	if strings.ContainsAny(patSegs[len(patSegs)-1], "*?[") {
+		return false
+	}
+
+	for n := len(pathSegs) - 1; n > 0; n-- {
+		if matchSegments(patSegs, pathSegs[:n]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func ValidateCodeOwnerPattern(pattern string) error {
+	trimmed := strings.Trim(pattern, "/")
+	if trimmed == "" || strings.ContainsAny(trimmed, " \t\n") {
+		return fmt.Errorf("%w: %q", apperrors.ErrInvalidCodeOwnerPattern, pattern)
+	}
+
+	for _, seg := range strings.Split(trimmed, "/") {
+		if _, err := path.Match(seg, ""); err != nil {
+			return fmt.Errorf("%w: %q", apperrors.ErrInvalidCodeOwnerPattern, pattern)
+		}
+	}
+
+	return nil
+}
+
+func matchSegments(pattern, segs []string) bool {
+	if len(pattern) == 0 {
+		return len(segs) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(segs); i++ {
+			if matchSegments(pattern[1:], segs[i:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(segs) == 0 {
+		return false
+	}
+
+	if ok, err := path.Match(pattern[0], segs[0]); err != nil || !ok {
+		return false
+	}
+
+	return matchSegments(pattern[1:], segs[1:])
+}
+
+// matchCodeOwners lets the last matching rule win, as in CODEOWNERS files.
+func matchCodeOwners(rules []model.CodeOwnerRule, paths []string) (matches []model.CodeOwnerMatch, unowned []string) {
+	byRule := make(map[int][]string, len(rules))
+
+	for _, p := range paths {
+		owner := -1
+
+		for i := len(rules) - 1; i >= 0; i-- {
+			if MatchCodeOwnerPattern(rules[i].Pattern, p) {
+				owner = i
+
+				break
+			}
+		}
+
+		if owner < 0 {
+			unowned = append(unowned, p)
+
+			continue
+		}
+
+		byRule[owner] = append(byRule[owner], p)
+	}
+
+	matches = make([]model.CodeOwnerMatch, 0, len(byRule))
+
+	for i, rule := range rules {
+		if matched, ok := byRule[i]; ok {
+			matches = append(matches, model.CodeOwnerMatch{
+				Rule:  rule,
+				Paths: matched,
+			})
+		}
+	}
+
+	return matches, unowned
+}
+
+type ownerGroup struct {
+	required   bool
+	candidates []model.ReviewerCandidate
+}
+
+// pickOwners gives every required group one owner even beyond count; missing counts groups with none eligible.
+func pickOwners(groups []ownerGroup, count int) (selected []string, unsatisfied int) {
+	selected = make([]string, 0, count)
+	taken := make(map[string]bool, count)
+
+	for _, group := range groups {
+		if !group.required {
+			continue
+		}
+
+		if slices.ContainsFunc(group.candidates, func(c model.ReviewerCandidate) bool { return taken[c.UserID] }) {
+			continue
+		}
+
+		if len(group.candidates) == 0 {
+			unsatisfied++
+
+			continue
+		}
+
+		owner := sortCandidates(group.candidates)[0].UserID
+		selected = append(selected, owner)
+		taken[owner] = true
+	}
+
+	preferred := make([]model.ReviewerCandidate, 0)
+	seen := make(map[string]bool)
+
+	for _, group := range groups {
+		for _, c := range group.candidates {
+			if !taken[c.UserID] && !seen[c.UserID] {
+				preferred = append(preferred, c)
+				seen[c.UserID] = true
+			}
+		}
+	}
+
+	for _, c := range sortCandidates(preferred) {
+		if len(selected) >= count {
+			break
+		}
+
+		selected = append(selected, c.UserID)
+	}
+
+	return selected, unsatisfied
+}
+
+type CodeOwnerRepositoryForCodeOwner interface {
+	Pool() *pgxpool.Pool
+
+	InsertCodeOwnerRule(ctx context.Context, ext repository.RepoExtension, rule *model.CodeOwnerRule) (*model.CodeOwnerRule, error)
+	SelectCodeOwnerRules(ctx context.Context, ext repository.RepoExtension, teamID int) ([]model.CodeOwnerRule, error)
+	DeleteCodeOwnerRule(ctx context.Context, ext repository.RepoExtension, id int64) (*model.CodeOwnerRule, error)
+}
+
+type TeamRepositoryForCodeOwner interface {
+	SelectTeamByName(ctx context.Context, ext repository.RepoExtension, teamName string) (*model.Team, error)
+	SelectTeamByID(ctx context.Context, ext repository.RepoExtension, teamID int) (*model.Team, error)
+}
+
+type UserRepositoryForCodeOwner interface {
+	SelectUserByID(ctx context.Context, ext repository.RepoExtension, userID string) (*model.User, error)
+}
+
+type CodeOwnerService struct {
+	codeOwnerRepo CodeOwnerRepositoryForCodeOwner
+	teamRepo      TeamRepositoryForCodeOwner
+	userRepo      UserRepositoryForCodeOwner
+	audit         auditWriter
+}
+
+func NewCodeOwnerService(
+	codeOwnerRepo CodeOwnerRepositoryForCodeOwner,
+	teamRepo TeamRepositoryForCodeOwner,
+	userRepo UserRepositoryForCodeOwner,
+	auditRepo AuditRepositoryForWrite,
+) *CodeOwnerService {
+	return &CodeOwnerService{
+		codeOwnerRepo: codeOwnerRepo,
+		teamRepo:      teamRepo,
+		userRepo:      userRepo,
+		audit:         auditWriter{repo: auditRepo},
+	}
+}
+
+func (s *CodeOwnerService) Add(ctx context.Context, req *model.AddCodeOwnerRuleRequest) (rule *model.CodeOwnerRule, err error) {
+	if err = ValidateCodeOwnerPattern(req.Pattern); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.codeOwnerRepo.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	team, err := s.teamRepo.SelectTeamByName(ctx, tx, req.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select team: %w", err)
+	}
+
+	users := compactStrings(req.Users)
+	teams := compactStrings(req.Teams)
+
+	for _, userID := range users {
+		if _, err = s.userRepo.SelectUserByID(ctx, tx, userID); err != nil {
+			return nil, fmt.Errorf("failed to select owner user: %w", err)
+		}
+	}
+
+	for _, teamName := range teams {
+		if _, err = s.teamRepo.SelectTeamByName(ctx, tx, teamName); err != nil {
+			return nil, fmt.Errorf("failed to select owner team: %w", err)
+		}
+	}
+
+	rule, err = s.codeOwnerRepo.InsertCodeOwnerRule(ctx, tx, &model.CodeOwnerRule{
+		TeamID:   team.ID,
+		Pattern:  req.Pattern,
+		Users:    users,
+		Teams:    teams,
+		Required: req.Required,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert code owner rule: %w", err)
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action:   model.AuditCodeOwnerRuleAdded,
+		teamName: team.Name,
+		after:    rule,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return rule, nil
+}
+
+func (s *CodeOwnerService) List(ctx context.Context, teamName string) (*model.CodeOwnerRulesResponse, error) {
+	team, err := s.teamRepo.SelectTeamByName(ctx, nil, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select team: %w", err)
+	}
+
+	rules, err := s.codeOwnerRepo.SelectCodeOwnerRules(ctx, nil, team.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select code owner rules: %w", err)
+	}
+
+	return &model.CodeOwnerRulesResponse{
+		TeamName: team.Name,
+		Rules:    rules,
+	}, nil
+}
+
+func (s *CodeOwnerService) Delete(ctx context.Context, id int64) (err error) {
+	tx, err := s.codeOwnerRepo.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	rule, err := s.codeOwnerRepo.DeleteCodeOwnerRule(ctx, tx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete code owner rule: %w", err)
+	}
+
+	team, err := s.teamRepo.SelectTeamByID(ctx, tx, rule.TeamID)
+	if err != nil {
+		return fmt.Errorf("failed to select team: %w", err)
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action:   model.AuditCodeOwnerRuleRemoved,
+		teamName: team.Name,
+		before:   rule,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (s *CodeOwnerService) Match(ctx context.Context, teamName string, paths []string) (*model.CodeOwnerMatchResponse, error) {
+	team, err := s.teamRepo.SelectTeamByName(ctx, nil, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select team: %w", err)
+	}
+
+	rules, err := s.codeOwnerRepo.SelectCodeOwnerRules(ctx, nil, team.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select code owner rules: %w", err)
+	}
+
+	matches, unowned := matchCodeOwners(rules, paths)
+	if unowned == nil {
+		unowned = []string{}
+	}
+
+	return &model.CodeOwnerMatchResponse{
+		TeamName:     team.Name,
+		Matches:      matches,
+		UnownedPaths: unowned,
+	}, nil
+}
+
+func compactStrings(values []string) []string {
+	out := make([]string, 0, len(values))
+
+	for _, v := range values {
+		if v != "" && !slices.Contains(out, v) {
+			out = append(out, v)
+		}
+	}
+
+	return out
+}
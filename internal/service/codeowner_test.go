@@ -0,0 +1,160 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+)
+
+func TestMatchCodeOwnerPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		want    bool
+	}{
+		{"*.go", "main.go", true},
+		{"*.go", "internal/service/pullrequest.go", true},
+		{"*.go", "README.md", false},
+		{"/README.md", "README.md", true},
+		{"/README.md", "docs/README.md", false},
+		{"README.md", "docs/README.md", true},
+		{"internal/service", "internal/service/codeowner.go", true},
+		{"internal/service/", "internal/service/sub/file.go", true},
+		{"internal/service", "cmd/internal/service/file.go", false},
+		{"internal/*", "internal/model/team.go", false},
+		{"internal/*", "internal/app.go", true},
+		{"internal/**", "internal/model/team.go", true},
+		{"**/migrations/*.sql", "db/migrations/000001_init.up.sql", true},
+		{"**/migrations/*.sql", "migrations/000001_init.up.sql", true},
+		{"docs/**/*.md", "docs/api/v1/index.md", true},
+		{"docs/**/*.md", "docs/index.md", true},
+		{"docs/**/*.md", "src/docs/index.md", false},
+		{"/", "main.go", false},
+	}
+
+	for _, tt := range tests {
+		if got := MatchCodeOwnerPattern(tt.pattern, tt.path); got != tt.want {
+			t.Errorf("MatchCodeOwnerPattern(%q, %q) = %v, want %v", tt.pattern, tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestValidateCodeOwnerPattern(t *testing.T) {
+	for _, pattern := range []string{"*.go", "/docs/", "internal/**/[a-z]*.go"} {
+		if err := ValidateCodeOwnerPattern(pattern); err != nil {
+			t.Errorf("ValidateCodeOwnerPattern(%q) = %v, want nil", pattern, err)
+		}
+	}
+
+	for _, pattern := range []string{"", "/", "a b", "internal/[a-"} {
+		if err := ValidateCodeOwnerPattern(pattern); !errors.Is(err, apperrors.ErrInvalidCodeOwnerPattern) {
+			t.Errorf("ValidateCodeOwnerPattern(%q) = %v, want ErrInvalidCodeOwnerPattern", pattern, err)
+		}
+	}
+}
+
+func TestMatchCodeOwners_LastRuleWins(t *testing.T) {
+	rules := []model.CodeOwnerRule{
+		{ID: 1, Pattern: "*.go", Users: []string{"u1"}},
+		{ID: 2, Pattern: "/internal/api/", Users: []string{"u2"}},
+		{ID: 3, Pattern: "*.md", Users: []string{"u3"}},
+	}
+
+	matches, unowned := matchCodeOwners(rules, []string{
+		"main.go",
+		"internal/api/http/handler/team.go",
+		"openapi.yaml",
+		"internal/model/team.go",
+	})
+
+	if len(matches) != 2 {
+		t.Fatalf("expected 2 matches, got %+v", matches)
+	}
+
+	if matches[0].Rule.ID != 1 || !slices.Equal(matches[0].Paths, []string{"main.go", "internal/model/team.go"}) {
+		t.Fatalf("unexpected first match %+v", matches[0])
+	}
+
+	if matches[1].Rule.ID != 2 || !slices.Equal(matches[1].Paths, []string{"internal/api/http/handler/team.go"}) {
+		t.Fatalf("unexpected second match %+v", matches[1])
+	}
+
+	if !slices.Equal(unowned, []string{"openapi.yaml"}) {
+		t.Fatalf("unexpected unowned paths %v", unowned)
+	}
+}
+
+func TestPickOwners(t *testing.T) {
+	candidate := func(id string, load int) model.ReviewerCandidate {
+		return model.ReviewerCandidate{UserID: id, OpenReviews: load}
+	}
+
+	tests := []struct {
+		name            string
+		groups          []ownerGroup
+		count           int
+		want            []string
+		wantUnsatisfied int
+	}{
+		{
+			name:  "no groups",
+			count: 2,
+			want:  []string{},
+		},
+		{
+			name: "preferred owners fill up to count by load",
+			groups: []ownerGroup{
+				{candidates: []model.ReviewerCandidate{candidate("a", 3), candidate("b", 1)}},
+				{candidates: []model.ReviewerCandidate{candidate("c", 0), candidate("b", 1)}},
+			},
+			count: 2,
+			want:  []string{"c", "b"},
+		},
+		{
+			name: "required group gets an owner even over count",
+			groups: []ownerGroup{
+				{candidates: []model.ReviewerCandidate{candidate("a", 0), candidate("b", 0)}},
+				{required: true, candidates: []model.ReviewerCandidate{candidate("c", 5)}},
+				{required: true, candidates: []model.ReviewerCandidate{candidate("d", 2), candidate("e", 1)}},
+			},
+			count: 1,
+			want:  []string{"c", "e"},
+		},
+		{
+			name: "shared owner covers several required groups",
+			groups: []ownerGroup{
+				{required: true, candidates: []model.ReviewerCandidate{candidate("a", 0), candidate("b", 1)}},
+				{required: true, candidates: []model.ReviewerCandidate{candidate("b", 1), candidate("a", 0)}},
+			},
+			count: 2,
+			want:  []string{"a", "b"},
+		},
+		{
+			name: "required group without eligible owners is unsatisfied",
+			groups: []ownerGroup{
+				{required: true},
+				{candidates: []model.ReviewerCandidate{candidate("a", 0)}},
+			},
+			count:           2,
+			want:            []string{"a"},
+			wantUnsatisfied: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, unsatisfied := pickOwners(tt.groups, tt.count)
+
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("selected %v, want %v", got, tt.want)
+			}
+
+			if unsatisfied != tt.wantUnsatisfied {
+				t.Fatalf("unsatisfied %d, want %d", unsatisfied, tt.wantUnsatisfied)
+			}
+		})
+	}
+}
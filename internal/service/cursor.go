@@ -0,0 +1,37 @@
+package service
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"avito-test-assignment/internal/apperrors"
+)
+
+const (
+	defaultPageLimit = 20
+	cursorSeparator  = "|"
+)
+
+func encodeCursor(t time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(t.UTC().Format(time.RFC3339Nano) + cursorSeparator + id))
+}
+
+func decodeCursor(cursor string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", apperrors.ErrInvalidCursor
+	}
+
+	ts, id, ok := strings.Cut(string(raw), cursorSeparator)
+	if !ok {
+		return time.Time{}, "", apperrors.ErrInvalidCursor
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return time.Time{}, "", apperrors.ErrInvalidCursor
+	}
+
+	return t, id, nil
+}
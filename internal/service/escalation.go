@@ -0,0 +1,214 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+	"avito-test-assignment/internal/repository"
+)
+
+const (
+	defaultEscalationCheckInterval = time.Minute
+	defaultEscalationBatchSize     = 100
+)
+
+type EscalationRepositoryForEscalation interface {
+	Pool() *pgxpool.Pool
+
+	ClaimStaleReviews(ctx context.Context, ext repository.RepoExtension, limit int) ([]model.StaleReview, error)
+	InsertEscalation(
+		ctx context.Context,
+		ext repository.RepoExtension,
+		stale *model.StaleReview,
+		newReviewerID string,
+	) (*model.ReviewEscalation, error)
+}
+
+type ReviewEscalator interface {
+	ReassignReviewer(ctx context.Context, ext repository.RepoExtension, pullRequestID, reviewerID, reason string) ([]string, error)
+	AddExtraReviewer(ctx context.Context, ext repository.RepoExtension, pullRequestID, reason string) (string, error)
+}
+
+type EscalationService struct {
+	escalationRepo EscalationRepositoryForEscalation
+	escalator      ReviewEscalator
+}
+
+func NewEscalationService(escalationRepo EscalationRepositoryForEscalation, escalator ReviewEscalator) *EscalationService {
+	return &EscalationService{
+		escalationRepo: escalationRepo,
+		escalator:      escalator,
+	}
+}
+
+// EscalateStale records a stale review nobody can take over so it is not retried. Each review is escalated
+// in its own savepoint; failures are left for the next run and returned joined.
+func (s *EscalationService) EscalateStale(ctx context.Context, limit int) ([]model.ReviewEscalation, error) {
+	escalations, failures, err := s.escalateStale(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return escalations, errors.Join(failures...)
+}
+
+func (s *EscalationService) escalateStale(
+	ctx context.Context,
+	limit int,
+) (escalations []model.ReviewEscalation, failures []error, err error) {
+	tx, err := s.escalationRepo.Pool().Begin(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	ctx, pending := withPendingMetrics(ctx)
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	stale, err := s.escalationRepo.ClaimStaleReviews(ctx, tx, limit)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to claim stale reviews: %w", err)
+	}
+
+	escalations = make([]model.ReviewEscalation, 0, len(stale))
+
+	for i := range stale {
+		var escalation *model.ReviewEscalation
+
+		failed, err := inSavepoint(ctx, tx, func(ctx context.Context, sp pgx.Tx) (err error) {
+			escalation, err = s.escalate(ctx, sp, &stale[i])
+
+			return err
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if failed != nil {
+			failures = append(failures, failed)
+
+			continue
+		}
+
+		escalations = append(escalations, *escalation)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	pending.flush()
+
+	return escalations, failures, nil
+}
+
+func (s *EscalationService) escalate(
+	ctx context.Context,
+	ext repository.RepoExtension,
+	stale *model.StaleReview,
+) (*model.ReviewEscalation, error) {
+	var (
+		newReviewer string
+		err         error
+	)
+
+	if stale.Action == model.StaleReviewAddReviewer {
+		newReviewer, err = s.escalator.AddExtraReviewer(ctx, ext, stale.PullRequestID, reassignReasonStale)
+	} else {
+		var newReviewers []string
+
+		newReviewers, err = s.escalator.ReassignReviewer(ctx, ext, stale.PullRequestID, stale.ReviewerID, reassignReasonStale)
+		if len(newReviewers) > 0 {
+			newReviewer = newReviewers[0]
+		}
+	}
+
+	if err != nil && !errors.Is(err, apperrors.ErrNoActiveReplacementCandidate) {
+		return nil, fmt.Errorf("failed to escalate review of %s on %s: %w", stale.ReviewerID, stale.PullRequestID, err)
+	}
+
+	escalation, err := s.escalationRepo.InsertEscalation(ctx, ext, stale, newReviewer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert escalation: %w", err)
+	}
+
+	return escalation, nil
+}
+
+type StaleReviewEscalator interface {
+	EscalateStale(ctx context.Context, limit int) ([]model.ReviewEscalation, error)
+}
+
+type EscalationScheduler struct {
+	l         *zap.Logger
+	svc       StaleReviewEscalator
+	interval  time.Duration
+	batchSize int
+}
+
+func NewEscalationScheduler(l *zap.Logger, svc StaleReviewEscalator, interval time.Duration, batchSize int) *EscalationScheduler {
+	if interval <= 0 {
+		interval = defaultEscalationCheckInterval
+	}
+
+	if batchSize <= 0 {
+		batchSize = defaultEscalationBatchSize
+	}
+
+	return &EscalationScheduler{
+		l:         l,
+		svc:       svc,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+func (w *EscalationScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		escalations, err := w.svc.EscalateStale(ctx, w.batchSize)
+		if err != nil && ctx.Err() == nil {
+			w.l.Error("Failed to escalate stale reviews", zap.Error(err))
+		}
+
+		for _, e := range escalations {
+			fields := []zap.Field{
+				zap.String("pull_request_id", e.PullRequestID),
+				zap.String("team_name", e.TeamName),
+				zap.String("reviewer_id", e.ReviewerID),
+				zap.Time("assigned_at", e.AssignedAt),
+				zap.String("action", e.Action),
+			}
+
+			if e.NewReviewerID == "" {
+				w.l.Warn("Stale review has no available reviewer to escalate to", fields...)
+
+				continue
+			}
+
+			w.l.Info("Stale review escalated", append(fields, zap.String("new_reviewer_id", e.NewReviewerID))...)
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+	"avito-test-assignment/internal/repository"
+)
+
+type fakeEscalationRepo struct {
+	EscalationRepositoryForEscalation
+
+	inserted []model.ReviewEscalation
+}
+
+func (r *fakeEscalationRepo) InsertEscalation(
+	_ context.Context,
+	_ repository.RepoExtension,
+	stale *model.StaleReview,
+	newReviewerID string,
+) (*model.ReviewEscalation, error) {
+	e := model.ReviewEscalation{
+		PullRequestID: stale.PullRequestID,
+		TeamName:      stale.TeamName,
+		ReviewerID:    stale.ReviewerID,
+		AssignedAt:    stale.AssignedAt,
+		Action:        stale.Action,
+		NewReviewerID: newReviewerID,
+	}
+
+	r.inserted = append(r.inserted, e)
+
+	return &e, nil
+}
+
+type fakeEscalator struct {
+	calls  []string
+	result string
+	err    error
+}
+
+func (f *fakeEscalator) ReassignReviewer(_ context.Context, _ repository.RepoExtension, prID, reviewerID, reason string) ([]string, error) {
+	f.calls = append(f.calls, "reassign "+prID+" "+reviewerID+" "+reason)
+
+	if f.result == "" {
+		return nil, f.err
+	}
+
+	return []string{f.result}, f.err
+}
+
+func (f *fakeEscalator) AddExtraReviewer(_ context.Context, _ repository.RepoExtension, prID, reason string) (string, error) {
+	f.calls = append(f.calls, "add "+prID+" "+reason)
+
+	return f.result, f.err
+}
+
+func TestEscalate(t *testing.T) {
+	errDeadlock := errors.New("deadlock detected")
+
+	tests := []struct {
+		name      string
+		action    string
+		result    string
+		err       error
+		wantCall  string
+		wantNew   string
+		wantError error
+	}{
+		{"reassign", model.StaleReviewReassign, "u3", nil, "reassign pr-1 u2 " + reassignReasonStale, "u3", nil},
+		{"add reviewer", model.StaleReviewAddReviewer, "u4", nil, "add pr-1 " + reassignReasonStale, "u4", nil},
+		{"no candidate is recorded", model.StaleReviewReassign, "", apperrors.ErrNoActiveReplacementCandidate, "reassign pr-1 u2 " + reassignReasonStale, "", nil},
+		{"failure is not recorded", model.StaleReviewAddReviewer, "", errDeadlock, "add pr-1 " + reassignReasonStale, "", errDeadlock},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeEscalationRepo{}
+			escalator := &fakeEscalator{result: tt.result, err: tt.err}
+			svc := NewEscalationService(repo, escalator)
+
+			stale := &model.StaleReview{PullRequestID: "pr-1", TeamName: "backend", ReviewerID: "u2", Action: tt.action}
+
+			escalation, err := svc.escalate(context.Background(), nil, stale)
+			if !errors.Is(err, tt.wantError) {
+				t.Fatalf("err = %v, want %v", err, tt.wantError)
+			}
+
+			if len(escalator.calls) != 1 || escalator.calls[0] != tt.wantCall {
+				t.Fatalf("escalator calls = %v, want [%s]", escalator.calls, tt.wantCall)
+			}
+
+			if tt.wantError != nil {
+				if len(repo.inserted) != 0 {
+					t.Fatalf("failed escalation was recorded: %+v", repo.inserted)
+				}
+
+				return
+			}
+
+			if escalation.NewReviewerID != tt.wantNew || escalation.Action != tt.action || len(repo.inserted) != 1 {
+				t.Fatalf("unexpected escalation %+v, recorded %d", escalation, len(repo.inserted))
+			}
+		})
+	}
+}
+
+type fakeStaleReviewEscalator struct {
+	runs   int
+	stop   context.CancelFunc
+	result [][]model.ReviewEscalation
+	errs   []error
+}
+
+func (f *fakeStaleReviewEscalator) EscalateStale(context.Context, int) ([]model.ReviewEscalation, error) {
+	run := f.runs
+	f.runs++
+
+	if f.runs == len(f.result) {
+		f.stop()
+	}
+
+	return f.result[run], f.errs[run]
+}
+
+func TestEscalationSchedulerRun(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	svc := &fakeStaleReviewEscalator{
+		stop: cancel,
+		result: [][]model.ReviewEscalation{
+			{{PullRequestID: "pr-1", ReviewerID: "u2", NewReviewerID: "u3"}},
+			{{PullRequestID: "pr-2", ReviewerID: "u4"}},
+		},
+		errs: []error{errors.New("escalate pr-9: deadlock detected"), nil},
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		NewEscalationScheduler(zap.New(core), svc, time.Millisecond, 10).Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scheduler did not stop after cancellation")
+	}
+
+	if svc.runs != 2 {
+		t.Fatalf("runs = %d, want 2", svc.runs)
+	}
+
+	want := []string{
+		"Failed to escalate stale reviews",
+		"Stale review escalated",
+		"Stale review has no available reviewer to escalate to",
+	}
+
+	entries := logs.AllUntimed()
+	if len(entries) != len(want) {
+		t.Fatalf("logged %d entries, want %d: %+v", len(entries), len(want), entries)
+	}
+
+	for i, msg := range want {
+		if entries[i].Message != msg {
+			t.Fatalf("entry %d = %q, want %q", i, entries[i].Message, msg)
+		}
+	}
+}
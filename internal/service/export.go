@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+	"avito-test-assignment/internal/repository"
+)
+
+type ExportRepositoryForExport interface {
+	StreamPullRequests(
+		ctx context.Context,
+		ext repository.RepoExtension,
+		filter *model.PullRequestFilter,
+		fn func(*model.PullRequestExportRow) error,
+	) error
+	StreamAssignmentEvents(
+		ctx context.Context,
+		ext repository.RepoExtension,
+		filter *model.AuditFilter,
+		fn func(*model.AssignmentExportRow) error,
+	) error
+}
+
+type ExportService struct {
+	exportRepo ExportRepositoryForExport
+}
+
+func NewExportService(exportRepo ExportRepositoryForExport) *ExportService {
+	return &ExportService{exportRepo: exportRepo}
+}
+
+func (s *ExportService) ExportPullRequests(
+	ctx context.Context,
+	qp *model.PullRequestExportQueryParam,
+	fn func(*model.PullRequestExportRow) error,
+) error {
+	if !validRange(qp.CreatedFrom, qp.CreatedTo) || !validRange(qp.MergedFrom, qp.MergedTo) {
+		return apperrors.ErrInvalidTimeRange
+	}
+
+	err := s.exportRepo.StreamPullRequests(ctx, nil, &model.PullRequestFilter{
+		AuthorID:    qp.AuthorID,
+		ReviewerID:  qp.ReviewerID,
+		TeamName:    qp.TeamName,
+		Status:      qp.Status,
+		CreatedFrom: qp.CreatedFrom,
+		CreatedTo:   qp.CreatedTo,
+		MergedFrom:  qp.MergedFrom,
+		MergedTo:    qp.MergedTo,
+	}, fn)
+	if err != nil {
+		return fmt.Errorf("failed to stream pull requests: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ExportService) ExportAssignments(
+	ctx context.Context,
+	qp *model.AssignmentExportQueryParam,
+	fn func(*model.AssignmentExportRow) error,
+) error {
+	if !validRange(qp.From, qp.To) {
+		return apperrors.ErrInvalidTimeRange
+	}
+
+	err := s.exportRepo.StreamAssignmentEvents(ctx, nil, &model.AuditFilter{
+		PullRequestID: qp.PullRequestID,
+		UserID:        qp.UserID,
+		TeamName:      qp.TeamName,
+		From:          qp.From,
+		To:            qp.To,
+	}, fn)
+	if err != nil {
+		return fmt.Errorf("failed to stream assignment events: %w", err)
+	}
+
+	return nil
+}
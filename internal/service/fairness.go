@@ -0,0 +1,157 @@
+package service
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"avito-test-assignment/internal/model"
+)
+
+const (
+	defaultFairnessWindow = 30 * 24 * time.Hour
+	fairShareTolerance    = 0.2
+)
+
+// teamFairness expects member assignments sorted by team.
+func teamFairness(
+	members []model.MemberAssignments,
+	unavailability []model.Unavailability,
+	to time.Time,
+) []model.TeamFairness {
+	periods := make(map[string][]model.Unavailability)
+
+	for _, u := range unavailability {
+		periods[u.UserID] = append(periods[u.UserID], u)
+	}
+
+	teams := make([]model.TeamFairness, 0)
+
+	for start := 0; start < len(members); {
+		end := start
+		for end < len(members) && members[end].TeamName == members[start].TeamName {
+			end++
+		}
+
+		teams = append(teams, memberFairness(members[start:end], periods, to))
+		start = end
+	}
+
+	return teams
+}
+
+func memberFairness(members []model.MemberAssignments, periods map[string][]model.Unavailability, to time.Time) model.TeamFairness {
+	team := model.TeamFairness{
+		TeamName: members[0].TeamName,
+		Members:  make([]model.MemberFairness, 0, len(members)),
+	}
+
+	var totalDays float64
+
+	days := make([]float64, len(members))
+	rates := make([]float64, 0, len(members))
+
+	for i, m := range members {
+		days[i] = activeDuration(m.ActiveFrom, to, periods[m.UserID]).Hours() / 24
+
+		team.Assignments += m.Assignments
+		totalDays += days[i]
+
+		if days[i] > 0 {
+			rates = append(rates, float64(m.Assignments)/days[i])
+		}
+
+		team.Members = append(team.Members, model.MemberFairness{
+			UserID:      m.UserID,
+			Username:    m.Username,
+			IsActive:    m.IsActive,
+			ActiveDays:  roundShare(days[i]),
+			Assignments: m.Assignments,
+		})
+	}
+
+	for i, m := range members {
+		member := &team.Members[i]
+		member.Status = model.FairShareFair
+
+		if totalDays == 0 || team.Assignments == 0 {
+			continue
+		}
+
+		share := float64(m.Assignments) / float64(team.Assignments)
+		expected := days[i] / totalDays
+
+		switch {
+		case share > expected*(1+fairShareTolerance):
+			member.Status = model.FairShareOver
+		case share < expected*(1-fairShareTolerance):
+			member.Status = model.FairShareUnder
+		}
+
+		member.Share = roundShare(share)
+		member.ExpectedShare = roundShare(expected)
+	}
+
+	team.GiniIndex = roundShare(giniIndex(rates))
+
+	return team
+}
+
+func activeDuration(from, to time.Time, periods []model.Unavailability) time.Duration {
+	if !from.Before(to) {
+		return 0
+	}
+
+	periods = slices.Clone(periods)
+	slices.SortFunc(periods, func(a, b model.Unavailability) int {
+		return a.StartsAt.Compare(b.StartsAt)
+	})
+
+	active := to.Sub(from)
+	cursor := from
+
+	for _, p := range periods {
+		start, end := p.StartsAt, p.EndsAt
+
+		if start.Before(cursor) {
+			start = cursor
+		}
+
+		if end.After(to) {
+			end = to
+		}
+
+		if !start.Before(end) {
+			continue
+		}
+
+		active -= end.Sub(start)
+		cursor = end
+	}
+
+	return active
+}
+
+func giniIndex(values []float64) float64 {
+	values = slices.Clone(values)
+	slices.Sort(values)
+
+	var sum, weighted float64
+
+	for i, v := range values {
+		sum += v
+		weighted += float64(i+1) * v
+	}
+
+	if sum == 0 {
+		return 0
+	}
+
+	n := float64(len(values))
+
+	return 2*weighted/(n*sum) - (n+1)/n
+}
+
+func roundShare(v float64) float64 {
+	return math.Round(v*10000) / 10000
+}
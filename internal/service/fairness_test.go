@@ -0,0 +1,92 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"avito-test-assignment/internal/model"
+)
+
+func TestGiniIndex(t *testing.T) {
+	tests := []struct {
+		values []float64
+		want   float64
+	}{
+		{nil, 0},
+		{[]float64{0, 0, 0}, 0},
+		{[]float64{2, 2, 2, 2}, 0},
+		{[]float64{0, 0, 0, 4}, 0.75},
+		{[]float64{1, 3}, 0.25},
+	}
+
+	for _, tt := range tests {
+		if got := giniIndex(tt.values); math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("giniIndex(%v) = %v, want %v", tt.values, got, tt.want)
+		}
+	}
+}
+
+func TestActiveDuration(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(10 * 24 * time.Hour)
+	day := func(d int) time.Time { return from.Add(time.Duration(d) * 24 * time.Hour) }
+
+	periods := []model.Unavailability{
+		{StartsAt: day(8), EndsAt: day(12)},
+		{StartsAt: day(-2), EndsAt: day(1)},
+		{StartsAt: day(3), EndsAt: day(5)},
+		{StartsAt: day(4), EndsAt: day(6)},
+	}
+
+	if got, want := activeDuration(from, to, periods), 4*24*time.Hour; got != want {
+		t.Fatalf("activeDuration = %v, want %v", got, want)
+	}
+
+	if got := activeDuration(to, to, nil); got != 0 {
+		t.Fatalf("activeDuration of empty window = %v, want 0", got)
+	}
+}
+
+func TestTeamFairness(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(10 * 24 * time.Hour)
+
+	members := []model.MemberAssignments{
+		{TeamName: "backend", UserID: "u1", ActiveFrom: from, Assignments: 6},
+		{TeamName: "backend", UserID: "u2", ActiveFrom: from, Assignments: 2},
+		{TeamName: "backend", UserID: "u3", ActiveFrom: from, Assignments: 2},
+		{TeamName: "frontend", UserID: "u4", ActiveFrom: from, Assignments: 0},
+	}
+
+	unavailability := []model.Unavailability{
+		{UserID: "u3", StartsAt: from, EndsAt: from.Add(5 * 24 * time.Hour)},
+	}
+
+	teams := teamFairness(members, unavailability, to)
+	if len(teams) != 2 {
+		t.Fatalf("expected 2 teams, got %+v", teams)
+	}
+
+	backend := teams[0]
+	if backend.TeamName != "backend" || backend.Assignments != 10 {
+		t.Fatalf("unexpected team %+v", backend)
+	}
+
+	wantStatus := []string{model.FairShareOver, model.FairShareUnder, model.FairShareFair}
+	wantExpected := []float64{0.4, 0.4, 0.2}
+
+	for i, m := range backend.Members {
+		if m.Status != wantStatus[i] || m.ExpectedShare != wantExpected[i] {
+			t.Errorf("member %s: status %s expected share %v, want %s %v", m.UserID, m.Status, m.ExpectedShare, wantStatus[i], wantExpected[i])
+		}
+	}
+
+	if backend.GiniIndex <= 0 {
+		t.Errorf("expected positive gini index, got %v", backend.GiniIndex)
+	}
+
+	if frontend := teams[1]; frontend.GiniIndex != 0 || frontend.Members[0].Status != model.FairShareFair {
+		t.Errorf("unexpected team without assignments %+v", frontend)
+	}
+}
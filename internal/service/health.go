@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"avito-test-assignment/internal/model"
+	"avito-test-assignment/internal/repository"
+)
+
+const readinessCheckTimeout = 2 * time.Second
+
+type HealthRepositoryForHealth interface {
+	Ping(ctx context.Context) error
+	SelectMigrationVersion(ctx context.Context, ext repository.RepoExtension) (uint64, bool, error)
+	PoolStats() model.PoolStats
+}
+
+type HealthService struct {
+	healthRepo       HealthRepositoryForHealth
+	migrationVersion uint64
+	draining         atomic.Bool
+}
+
+// NewHealthService reads the migrations once, so readiness checks against the ones shipped with this binary.
+func NewHealthService(healthRepo HealthRepositoryForHealth, migrationsPath string) (*HealthService, error) {
+	version, err := LatestMigrationVersion(migrationsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HealthService{
+		healthRepo:       healthRepo,
+		migrationVersion: version,
+	}, nil
+}
+
+func (s *HealthService) Drain() {
+	s.draining.Store(true)
+}
+
+func (s *HealthService) Ready(ctx context.Context) (*model.ReadinessResponse, bool) {
+	if s.draining.Load() {
+		return &model.ReadinessResponse{Status: model.HealthStatusDraining}, false
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+
+	checks := &model.ReadinessChecks{
+		Database:   model.HealthCheck{Status: model.HealthStatusOK},
+		Migrations: model.MigrationCheck{HealthCheck: model.HealthCheck{Status: model.HealthStatusOK}, Expected: s.migrationVersion},
+		Pool:       poolCheck(s.healthRepo.PoolStats()),
+	}
+
+	if err := s.healthRepo.Ping(ctx); err != nil {
+		checks.Database = model.HealthCheck{Status: model.HealthStatusFail, Error: err.Error()}
+		checks.Migrations.HealthCheck = model.HealthCheck{Status: model.HealthStatusFail, Error: "database unavailable"}
+	} else {
+		checks.Migrations = s.migrationCheck(ctx)
+	}
+
+	ready := checks.Database.Status == model.HealthStatusOK && checks.Migrations.Status == model.HealthStatusOK
+
+	status := model.HealthStatusOK
+	if !ready {
+		status = model.HealthStatusFail
+	}
+
+	return &model.ReadinessResponse{Status: status, Checks: checks}, ready
+}
+
+func (s *HealthService) migrationCheck(ctx context.Context) model.MigrationCheck {
+	check := model.MigrationCheck{
+		HealthCheck: model.HealthCheck{Status: model.HealthStatusOK},
+		Expected:    s.migrationVersion,
+	}
+
+	version, dirty, err := s.healthRepo.SelectMigrationVersion(ctx, nil)
+	if err != nil {
+		check.HealthCheck = model.HealthCheck{Status: model.HealthStatusFail, Error: err.Error()}
+
+		return check
+	}
+
+	check.Version, check.Dirty = version, dirty
+
+	switch {
+	case dirty:
+		check.HealthCheck = model.HealthCheck{Status: model.HealthStatusFail, Error: "migration is dirty"}
+	case version != s.migrationVersion:
+		check.HealthCheck = model.HealthCheck{
+			Status: model.HealthStatusFail,
+			Error:  fmt.Sprintf("database is at version %d, expected %d", version, s.migrationVersion),
+		}
+	}
+
+	return check
+}
+
+// poolCheck is informational: a busy pool still serves requests, it just queues them.
+func poolCheck(stats model.PoolStats) model.PoolCheck {
+	check := model.PoolCheck{PoolStats: stats}
+
+	if stats.MaxConns > 0 {
+		check.Saturation = float64(stats.AcquiredConns) / float64(stats.MaxConns)
+	}
+
+	return check
+}
+
+func LatestMigrationVersion(dir string) (uint64, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	var latest uint64
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+
+		prefix, _, found := strings.Cut(name, "_")
+		if !found {
+			continue
+		}
+
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		latest = max(latest, version)
+	}
+
+	if latest == 0 {
+		return 0, fmt.Errorf("no migrations found in %s", dir)
+	}
+
+	return latest, nil
+}
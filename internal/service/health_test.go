@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"avito-test-assignment/internal/model"
+	"avito-test-assignment/internal/repository"
+)
+
+type fakeHealthRepo struct {
+	pingErr error
+	version uint64
+	dirty   bool
+	stats   model.PoolStats
+}
+
+func (r *fakeHealthRepo) Ping(context.Context) error {
+	return r.pingErr
+}
+
+func (r *fakeHealthRepo) SelectMigrationVersion(context.Context, repository.RepoExtension) (uint64, bool, error) {
+	return r.version, r.dirty, nil
+}
+
+func (r *fakeHealthRepo) PoolStats() model.PoolStats {
+	return r.stats
+}
+
+func TestLatestMigrationVersion(t *testing.T) {
+	dir := t.TempDir()
+
+	for _, name := range []string{
+		"000001_init.up.sql",
+		"000001_init.down.sql",
+		"000012_add_audit.up.sql",
+		"000013_add_webhooks.down.sql",
+		"README.md",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	version, err := LatestMigrationVersion(dir)
+	if err != nil || version != 12 {
+		t.Fatalf("LatestMigrationVersion = %d, %v, want 12", version, err)
+	}
+
+	if _, err := LatestMigrationVersion(t.TempDir()); err == nil {
+		t.Fatal("expected error for a directory without migrations")
+	}
+}
+
+func TestHealthReady(t *testing.T) {
+	stats := model.PoolStats{AcquiredConns: 3, IdleConns: 1, TotalConns: 4, MaxConns: 4}
+
+	tests := []struct {
+		name   string
+		repo   *fakeHealthRepo
+		ready  bool
+		failed string
+	}{
+		{"ok", &fakeHealthRepo{version: 19, stats: stats}, true, ""},
+		{"db down", &fakeHealthRepo{pingErr: errors.New("connection refused"), version: 19}, false, "database"},
+		{"behind", &fakeHealthRepo{version: 18}, false, "migrations"},
+		{"dirty", &fakeHealthRepo{version: 19, dirty: true}, false, "migrations"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &HealthService{healthRepo: tt.repo, migrationVersion: 19}
+
+			response, ready := svc.Ready(context.Background())
+			if ready != tt.ready {
+				t.Fatalf("ready = %v, want %v: %+v", ready, tt.ready, response.Checks)
+			}
+
+			switch tt.failed {
+			case "database":
+				if response.Checks.Database.Status != model.HealthStatusFail {
+					t.Fatalf("database check not failed: %+v", response.Checks.Database)
+				}
+			case "migrations":
+				if response.Checks.Database.Status != model.HealthStatusOK || response.Checks.Migrations.Status != model.HealthStatusFail {
+					t.Fatalf("migrations check not failed: %+v", response.Checks)
+				}
+			}
+
+			if tt.ready && response.Checks.Pool.Saturation != 0.75 {
+				t.Fatalf("saturation = %v, want 0.75", response.Checks.Pool.Saturation)
+			}
+		})
+	}
+
+	svc := &HealthService{healthRepo: &fakeHealthRepo{version: 19}, migrationVersion: 19}
+	svc.Drain()
+
+	if response, ready := svc.Ready(context.Background()); ready || response.Status != model.HealthStatusDraining {
+		t.Fatalf("expected draining, got %+v", response)
+	}
+}
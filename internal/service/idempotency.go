@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/repository"
+)
+
+const defaultIdempotencyCleanupInterval = time.Hour
+
+type IdempotencyRepositoryForJanitor interface {
+	DeleteExpired(ctx context.Context, ext repository.RepoExtension) (int64, error)
+}
+
+type IdempotencyJanitor struct {
+	l        *zap.Logger
+	repo     IdempotencyRepositoryForJanitor
+	interval time.Duration
+}
+
+func NewIdempotencyJanitor(l *zap.Logger, repo IdempotencyRepositoryForJanitor, interval time.Duration) *IdempotencyJanitor {
+	if interval <= 0 {
+		interval = defaultIdempotencyCleanupInterval
+	}
+
+	return &IdempotencyJanitor{
+		l:        l,
+		repo:     repo,
+		interval: interval,
+	}
+}
+
+func (j *IdempotencyJanitor) Run(ctx context.Context) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		deleted, err := j.repo.DeleteExpired(ctx, nil)
+		if err != nil {
+			if ctx.Err() == nil {
+				j.l.Error("Failed to delete expired idempotency keys", zap.Error(err))
+			}
+
+			continue
+		}
+
+		j.l.Debug("Expired idempotency keys deleted", zap.Int64("count", deleted))
+	}
+}
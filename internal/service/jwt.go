@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/actor"
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/jwt"
+	"avito-test-assignment/internal/repository"
+)
+
+const (
+	defaultJWTUserClaim       = "sub"
+	defaultJWTRoleClaim       = "role"
+	defaultJWKSReloadInterval = time.Minute
+)
+
+// rolePrecedence lists the most privileged first, so several roles in a claim resolve to the strongest.
+var rolePrecedence = []string{actor.RoleAdmin, actor.RoleTeamLead, actor.RoleMember}
+
+type JWTVerifier interface {
+	Verify(token string) (jwt.Claims, error)
+}
+
+type AuthRepositoryForJWT interface {
+	SelectUserRole(ctx context.Context, ext repository.RepoExtension, userID string) (string, error)
+	SelectUserTeamNames(ctx context.Context, ext repository.RepoExtension, userID string) ([]string, error)
+}
+
+// JWTAuthenticator uses the stored role of the user when no role claim is recognized.
+type JWTAuthenticator struct {
+	verifier  JWTVerifier
+	authRepo  AuthRepositoryForJWT
+	userClaim string
+	roleClaim string
+}
+
+func NewJWTAuthenticator(verifier JWTVerifier, authRepo AuthRepositoryForJWT, userClaim, roleClaim string) *JWTAuthenticator {
+	if userClaim == "" {
+		userClaim = defaultJWTUserClaim
+	}
+
+	if roleClaim == "" {
+		roleClaim = defaultJWTRoleClaim
+	}
+
+	return &JWTAuthenticator{
+		verifier:  verifier,
+		authRepo:  authRepo,
+		userClaim: userClaim,
+		roleClaim: roleClaim,
+	}
+}
+
+func (a *JWTAuthenticator) Authenticate(ctx context.Context, token string) (*actor.Actor, error) {
+	claims, err := a.verifier.Verify(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", apperrors.ErrUnauthorized, err)
+	}
+
+	var userID string
+
+	if ids := claims.Strings(a.userClaim); len(ids) == 1 {
+		userID = ids[0]
+	}
+
+	if userID == "" {
+		return nil, fmt.Errorf("%w: missing %s claim", apperrors.ErrUnauthorized, a.userClaim)
+	}
+
+	storedRole, err := a.authRepo.SelectUserRole(ctx, nil, userID)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrUserNotExist) {
+			return nil, fmt.Errorf("%w: %w", apperrors.ErrUnauthorized, err)
+		}
+
+		return nil, fmt.Errorf("failed to select user role: %w", err)
+	}
+
+	teams, err := a.authRepo.SelectUserTeamNames(ctx, nil, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select user teams: %w", err)
+	}
+
+	role := storedRole
+	if claimed := claimedRole(claims.Strings(a.roleClaim)); claimed != "" {
+		role = claimed
+	}
+
+	return &actor.Actor{ID: userID, Role: role, Teams: teams}, nil
+}
+
+func claimedRole(values []string) string {
+	for _, role := range rolePrecedence {
+		if slices.Contains(values, role) {
+			return role
+		}
+	}
+
+	return ""
+}
+
+type JWKSReloadable interface {
+	Reload(ctx context.Context) (bool, error)
+}
+
+type JWKSReloader struct {
+	l        *zap.Logger
+	keys     JWKSReloadable
+	interval time.Duration
+}
+
+func NewJWKSReloader(l *zap.Logger, keys JWKSReloadable, interval time.Duration) *JWKSReloader {
+	if interval <= 0 {
+		interval = defaultJWKSReloadInterval
+	}
+
+	return &JWKSReloader{
+		l:        l,
+		keys:     keys,
+		interval: interval,
+	}
+}
+
+func (r *JWKSReloader) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		changed, err := r.keys.Reload(ctx)
+		if err != nil {
+			if ctx.Err() == nil {
+				r.l.Error("Failed to reload JWKS", zap.Error(err))
+			}
+
+			continue
+		}
+
+		if changed {
+			r.l.Info("JWKS reloaded")
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"slices"
+
+	"avito-test-assignment/internal/apperrors"
+)
+
+const (
+	prStatusDraft    = "DRAFT"
+	prStatusOpen     = "OPEN"
+	prStatusMerged   = "MERGED"
+	prStatusClosed   = "CLOSED"
+	prStatusReopened = "REOPENED"
+)
+
+var prTransitions = map[string][]string{
+	prStatusDraft:    {prStatusOpen, prStatusClosed},
+	prStatusOpen:     {prStatusMerged, prStatusClosed},
+	prStatusReopened: {prStatusMerged, prStatusClosed},
+	prStatusClosed:   {prStatusReopened},
+}
+
+func checkTransition(from, to string) error {
+	if slices.Contains(prTransitions[from], to) {
+		return nil
+	}
+
+	switch from {
+	case prStatusMerged:
+		return apperrors.ErrPullRequestAlreadyMerged
+	case prStatusClosed:
+		return apperrors.ErrPullRequestClosed
+	case prStatusDraft:
+		return apperrors.ErrPullRequestIsDraft
+	default:
+		return apperrors.ErrInvalidStatusTransition
+	}
+}
+
+func openStatusError(status string) error {
+	switch status {
+	case prStatusMerged:
+		return apperrors.ErrPullRequestAlreadyMerged
+	case prStatusClosed:
+		return apperrors.ErrPullRequestClosed
+	case prStatusDraft:
+		return apperrors.ErrPullRequestIsDraft
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,34 @@
+package service
+
+import "context"
+
+type pendingMetricsKey struct{}
+
+// pendingMetrics holds metrics until the transaction commits, so rolled back work is not counted.
+type pendingMetrics struct {
+	events []func()
+}
+
+func withPendingMetrics(ctx context.Context) (context.Context, *pendingMetrics) {
+	pending := &pendingMetrics{}
+
+	return context.WithValue(ctx, pendingMetricsKey{}, pending), pending
+}
+
+func recordMetric(ctx context.Context, fn func()) {
+	if pending, ok := ctx.Value(pendingMetricsKey{}).(*pendingMetrics); ok {
+		pending.events = append(pending.events, fn)
+
+		return
+	}
+
+	fn()
+}
+
+func (p *pendingMetrics) flush() {
+	for _, fn := range p.events {
+		fn()
+	}
+
+	p.events = nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+type fakeTx struct {
+	pgx.Tx
+}
+
+func (tx *fakeTx) Begin(context.Context) (pgx.Tx, error) { return &fakeTx{}, nil }
+
+func (tx *fakeTx) Commit(context.Context) error { return nil }
+
+func (tx *fakeTx) Rollback(context.Context) error { return nil }
+
+func TestRecordMetricWithoutTransaction(t *testing.T) {
+	recorded := 0
+
+	recordMetric(context.Background(), func() { recorded++ })
+
+	if recorded != 1 {
+		t.Fatalf("recorded %d, want 1", recorded)
+	}
+}
+
+func TestRecordMetricWaitsForCommit(t *testing.T) {
+	ctx, pending := withPendingMetrics(context.Background())
+	recorded := 0
+
+	recordMetric(ctx, func() { recorded++ })
+
+	if recorded != 0 {
+		t.Fatal("metric recorded before commit")
+	}
+
+	pending.flush()
+	pending.flush()
+
+	if recorded != 1 {
+		t.Fatalf("recorded %d, want 1", recorded)
+	}
+}
+
+func TestSavepointMetrics(t *testing.T) {
+	ctx, pending := withPendingMetrics(context.Background())
+
+	var recorded []string
+
+	_, err := inSavepoint(ctx, &fakeTx{}, func(ctx context.Context, _ pgx.Tx) error {
+		recordMetric(ctx, func() { recorded = append(recorded, "released") })
+
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("inSavepoint: %v", err)
+	}
+
+	failed, err := inSavepoint(ctx, &fakeTx{}, func(ctx context.Context, _ pgx.Tx) error {
+		recordMetric(ctx, func() { recorded = append(recorded, "rolled back") })
+
+		return errors.New("no candidate")
+	})
+	if err != nil || failed == nil {
+		t.Fatalf("inSavepoint: failed %v, err %v", failed, err)
+	}
+
+	if len(recorded) != 0 {
+		t.Fatalf("recorded %v before commit", recorded)
+	}
+
+	pending.flush()
+
+	if len(recorded) != 1 || recorded[0] != "released" {
+		t.Fatalf("recorded %v, want only the released savepoint", recorded)
+	}
+}
@@ -2,32 +2,59 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"avito-test-assignment/internal/actor"
 	"avito-test-assignment/internal/apperrors"
 	"avito-test-assignment/internal/model"
 	"avito-test-assignment/internal/repository"
+	"avito-test-assignment/internal/tracing"
 )
 
 const (
-	prStatusMerged = "MERGED"
+	assignOperationAssign   = "assign"
+	assignOperationReassign = "reassign"
+	assignOperationAdd      = "add_reviewer"
 )
 
 type PullRequestRepositoryForPR interface {
 	Pool() *pgxpool.Pool
 
-	InsertPullRequest(ctx context.Context, ext repository.RepoExtension, id, name, authorID string) (*model.PullRequest, error)
-	SetReviewers(ctx context.Context, ext repository.RepoExtension, authorID, prID string) ([]string, error)
-	MergePullRequest(ctx context.Context, ext repository.RepoExtension, prID string) error
+	InsertPullRequest(
+		ctx context.Context,
+		ext repository.RepoExtension,
+		id, name, authorID, status string,
+		teamID int,
+		changedPaths []string,
+	) (*model.PullRequest, error)
+	GetReviewerCandidatesForTeam(ctx context.Context, ext repository.RepoExtension, teamID int, authorID string) ([]model.ReviewerCandidate, error)
+	GetCandidatesAmong(
+		ctx context.Context,
+		ext repository.RepoExtension,
+		userIDs, teamNames []string,
+		authorID string,
+	) ([]model.ReviewerCandidate, error)
+	LockReviewersBelowCapacity(ctx context.Context, ext repository.RepoExtension, userIDs []string) ([]string, error)
+	SetReviewers(ctx context.Context, ext repository.RepoExtension, prID string, reviewerIDs []string) ([]string, error)
+	MergePullRequest(ctx context.Context, ext repository.RepoExtension, prID, prevStatus, mergedBy string) error
+	UpdatePullRequestStatus(ctx context.Context, ext repository.RepoExtension, prID, prevStatus, status string) error
 	GetAssignedReviewers(ctx context.Context, ext repository.RepoExtension, prID string) ([]string, error)
 	SelectPullRequestByID(ctx context.Context, ext repository.RepoExtension, id string) (*model.PullRequest, error)
+	SelectPullRequestByIDForUpdate(ctx context.Context, ext repository.RepoExtension, id string) (*model.PullRequest, error)
 	GetPRStatus(ctx context.Context, ext repository.RepoExtension, prID string) (string, error)
-	GetReviewerCandidates(ctx context.Context, ext repository.RepoExtension, oldReviewerID, prID string) ([]string, error)
+	GetReviewerCandidates(ctx context.Context, ext repository.RepoExtension, oldReviewerID, prID string) ([]model.ReviewerCandidate, error)
 	RemoveReviewer(ctx context.Context, ext repository.RepoExtension, prID, reviewerID string) error
 	AddReviewer(ctx context.Context, ext repository.RepoExtension, prID, reviewerID string) error
 	IsReviewerAssigned(ctx context.Context, ext repository.RepoExtension, prID, reviewerID string) (bool, error)
+	InsertReview(ctx context.Context, ext repository.RepoExtension, prID, reviewerID, verdict, comment string) (*model.Review, error)
+	GetLatestReviews(ctx context.Context, ext repository.RepoExtension, prID string) ([]model.Review, error)
+	CountApprovals(ctx context.Context, ext repository.RepoExtension, prID string) (int, error)
+	SelectPullRequests(ctx context.Context, ext repository.RepoExtension, filter *model.PullRequestFilter) ([]*model.PullRequest, error)
+	SelectPullRequestsByUserID(ctx context.Context, ext repository.RepoExtension, userID string) ([]*model.PullRequest, error)
 }
 
 type UserRepositoryForPR interface {
@@ -35,33 +62,78 @@ type UserRepositoryForPR interface {
 }
 
 type TeamRepositoryForPR interface {
-	SelectTeamIDByUserID(ctx context.Context, ext repository.RepoExtension, userID string) (int, error)
+	SelectTeamByID(ctx context.Context, ext repository.RepoExtension, teamID int) (*model.Team, error)
+	SelectTeamsByUserID(ctx context.Context, ext repository.RepoExtension, userID string) ([]model.Team, error)
+}
+
+type CodeOwnerRepositoryForPR interface {
+	SelectCodeOwnerRules(ctx context.Context, ext repository.RepoExtension, teamID int) ([]model.CodeOwnerRule, error)
+}
+
+type ReviewerPoolRepositoryForPR interface {
+	SelectTeamReviewerPools(ctx context.Context, ext repository.RepoExtension, teamID int) ([]model.TeamReviewerPool, error)
+}
+
+type PullRequestMetrics interface {
+	PullRequestCreated()
+	PullRequestMerged()
+	ReviewerReassigned(reason string)
+	AssignmentFailed(operation string)
 }
 
 type PullRequestService struct {
 	pullRequestRepo PullRequestRepositoryForPR
 	userRepo        UserRepositoryForPR
 	teamRepo        TeamRepositoryForPR
+	codeOwnerRepo   CodeOwnerRepositoryForPR
+	poolRepo        ReviewerPoolRepositoryForPR
+	audit           auditWriter
+	outbox          outboxWriter
+	selector        ReviewerSelector
+	metrics         PullRequestMetrics
 }
 
 func NewPullRequestService(
 	pullRequestRepo PullRequestRepositoryForPR,
 	userRepo UserRepositoryForPR,
 	teamRepo TeamRepositoryForPR,
+	codeOwnerRepo CodeOwnerRepositoryForPR,
+	poolRepo ReviewerPoolRepositoryForPR,
+	auditRepo AuditRepositoryForWrite,
+	outboxRepo OutboxRepositoryForWrite,
+	selector ReviewerSelector,
+	metrics PullRequestMetrics,
 ) *PullRequestService {
 	return &PullRequestService{
 		pullRequestRepo: pullRequestRepo,
 		userRepo:        userRepo,
 		teamRepo:        teamRepo,
+		codeOwnerRepo:   codeOwnerRepo,
+		poolRepo:        poolRepo,
+		audit:           auditWriter{repo: auditRepo},
+		outbox:          outboxWriter{repo: outboxRepo},
+		selector:        selector,
+		metrics:         metrics,
 	}
 }
 
-func (s *PullRequestService) Create(ctx context.Context, id, name, authorID string) (*model.PullRequestWithAssignedReviewers, error) {
+func (s *PullRequestService) Create(
+	ctx context.Context,
+	id, name, authorID string,
+	teamID *int,
+	draft bool,
+	changedPaths []string,
+) (response *model.PullRequestWithAssignedReviewers, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.Create")
+	defer tracing.End(span, &err)
+
 	tx, err := s.pullRequestRepo.Pool().Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
+	ctx, pending := withPendingMetrics(ctx)
+
 	defer func() {
 		if err != nil {
 			if rErr := tx.Rollback(ctx); rErr != nil {
@@ -75,35 +147,71 @@ func (s *PullRequestService) Create(ctx context.Context, id, name, authorID stri
 		return nil, err
 	}
 
-	_, err = s.teamRepo.SelectTeamIDByUserID(ctx, tx, authorID)
+	team, err := s.resolveTeam(ctx, tx, authorID, teamID)
 	if err != nil {
 		return nil, err
 	}
 
-	pr, err := s.pullRequestRepo.InsertPullRequest(ctx, tx, id, name, authorID)
+	status := prStatusOpen
+	if draft {
+		status = prStatusDraft
+	}
+
+	pr, err := s.pullRequestRepo.InsertPullRequest(ctx, tx, id, name, authorID, status, team.ID, compactStrings(changedPaths))
 	if err != nil {
 		return nil, fmt.Errorf("failed to insert pull request: %w", err)
 	}
 
-	rIDs, err := s.pullRequestRepo.SetReviewers(ctx, tx, authorID, pr.PullRequestID)
+	err = s.audit.record(ctx, tx, auditRecord{
+		action:        model.AuditPullRequestCreated,
+		pullRequestID: pr.PullRequestID,
+		userID:        pr.AuthorID,
+		teamName:      team.Name,
+		after: map[string]any{
+			"pull_request_name": pr.PullRequestName,
+			"status":            pr.Status,
+			"team_id":           pr.TeamID,
+			"changed_paths":     pr.ChangedPaths,
+		},
+	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to set reviewers: %w", err)
+		return nil, err
+	}
+
+	assignment := &reviewerAssignment{reviewers: []string{}}
+
+	if !draft {
+		assignment, err = s.assignReviewers(ctx, tx, pr)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	if err = tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	pending.flush()
+
+	s.metrics.PullRequestCreated()
+
 	return &model.PullRequestWithAssignedReviewers{
-		PullRequestID:   pr.PullRequestID,
-		PullRequestName: pr.PullRequestName,
-		AuthorID:        pr.AuthorID,
-		Status:          pr.Status,
-		Assigned:        rIDs,
+		PullRequestID:    pr.PullRequestID,
+		PullRequestName:  pr.PullRequestName,
+		AuthorID:         pr.AuthorID,
+		Status:           pr.Status,
+		TeamID:           pr.TeamID,
+		Assigned:         assignment.reviewers,
+		OwnerReviewers:   assignment.owners,
+		PoolReviewers:    assignment.pool,
+		MissingReviewers: assignment.missing,
 	}, nil
 }
 
-func (s *PullRequestService) Merge(ctx context.Context, pullRequestID string) (*model.MergedResponse, error) {
+func (s *PullRequestService) Merge(ctx context.Context, pullRequestID string) (response *model.MergedResponse, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.Merge")
+	defer tracing.End(span, &err)
+
 	tx, err := s.pullRequestRepo.Pool().Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
@@ -117,24 +225,37 @@ func (s *PullRequestService) Merge(ctx context.Context, pullRequestID string) (*
 		}
 	}()
 
-	if err := s.pullRequestRepo.MergePullRequest(ctx, tx, pullRequestID); err != nil {
-		return nil, fmt.Errorf("failed to merge pull request: %w", err)
-	}
-
-	pr, err := s.pullRequestRepo.SelectPullRequestByID(ctx, tx, pullRequestID)
+	pr, err := s.pullRequestRepo.SelectPullRequestByIDForUpdate(ctx, tx, pullRequestID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to select pull request by ID: %w", err)
 	}
 
+	justMerged := pr.Status != prStatusMerged
+
+	if justMerged {
+		if pr, err = s.merge(ctx, tx, pr); err != nil {
+			return nil, err
+		}
+	}
+
 	reviewers, err := s.pullRequestRepo.GetAssignedReviewers(ctx, tx, pr.PullRequestID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to select assigned reviewers: %w", err)
 	}
 
+	reviews, err := s.pullRequestRepo.GetLatestReviews(ctx, tx, pr.PullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviews: %w", err)
+	}
+
 	if err = tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	if justMerged {
+		s.metrics.PullRequestMerged()
+	}
+
 	return &model.MergedResponse{
 		PullRequestWithAssignedReviewers: model.PullRequestWithAssignedReviewers{
 			PullRequestID:   pr.PullRequestID,
@@ -142,17 +263,76 @@ func (s *PullRequestService) Merge(ctx context.Context, pullRequestID string) (*
 			AuthorID:        pr.AuthorID,
 			Status:          pr.Status,
 			Assigned:        reviewers,
+			Reviews:         reviews,
 		},
 		MergedAt: *pr.MergedAt,
+		MergedBy: pr.MergedBy,
 	}, nil
 }
 
-func (s *PullRequestService) Reassign(ctx context.Context, pullRequestID, oldReviewerID string) (*model.ReassignResponse, error) {
+func (s *PullRequestService) merge(ctx context.Context, ext repository.RepoExtension, pr *model.PullRequest) (*model.PullRequest, error) {
+	if err := checkTransition(pr.Status, prStatusMerged); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkApprovals(ctx, ext, pr); err != nil {
+		return nil, err
+	}
+
+	prevStatus := pr.Status
+
+	if err := s.pullRequestRepo.MergePullRequest(ctx, ext, pr.PullRequestID, prevStatus, actor.FromContext(ctx)); err != nil {
+		return nil, fmt.Errorf("failed to merge pull request: %w", err)
+	}
+
+	pr, err := s.pullRequestRepo.SelectPullRequestByID(ctx, ext, pr.PullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select pull request by ID: %w", err)
+	}
+
+	err = s.audit.record(ctx, ext, auditRecord{
+		action:        model.AuditPullRequestMerged,
+		pullRequestID: pr.PullRequestID,
+		before:        map[string]any{"status": prevStatus},
+		after:         map[string]any{"status": pr.Status, "merged_at": pr.MergedAt, "merged_by": pr.MergedBy},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers, err := s.pullRequestRepo.GetAssignedReviewers(ctx, ext, pr.PullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select assigned reviewers: %w", err)
+	}
+
+	err = s.outbox.publish(ctx, ext, model.WebhookEventPullRequestMerged, model.PullRequestEventData{
+		PullRequestID:   pr.PullRequestID,
+		PullRequestName: pr.PullRequestName,
+		AuthorID:        pr.AuthorID,
+		Status:          pr.Status,
+		TeamID:          pr.TeamID,
+		Reviewers:       reviewers,
+		MergedAt:        pr.MergedAt,
+		MergedBy:        pr.MergedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+func (s *PullRequestService) Reassign(ctx context.Context, pullRequestID, oldReviewerID string) (response *model.ReassignResponse, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.Reassign")
+	defer tracing.End(span, &err)
+
 	tx, err := s.pullRequestRepo.Pool().Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
+	ctx, pending := withPendingMetrics(ctx)
+
 	defer func() {
 		if err != nil {
 			if rErr := tx.Rollback(ctx); rErr != nil {
@@ -161,16 +341,60 @@ func (s *PullRequestService) Reassign(ctx context.Context, pullRequestID, oldRev
 		}
 	}()
 
-	pr, err := s.pullRequestRepo.SelectPullRequestByID(ctx, tx, pullRequestID)
+	pr, err := s.pullRequestRepo.SelectPullRequestByIDForUpdate(ctx, tx, pullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select pull request by ID: %w", err)
+	}
+
+	newReviewers, err := s.reassign(ctx, tx, pr, oldReviewerID, reassignReasonManual)
+	if err != nil {
+		if errors.Is(err, apperrors.ErrNoActiveReplacementCandidate) {
+			s.metrics.AssignmentFailed(assignOperationReassign)
+		}
+
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	pending.flush()
+
+	pr, err = s.pullRequestRepo.SelectPullRequestByID(ctx, nil, pullRequestID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to select pull request by ID: %w", err)
 	}
 
-	if pr.Status == prStatusMerged {
-		return nil, apperrors.ErrPullRequestAlreadyMerged
+	assigned, err := s.pullRequestRepo.GetAssignedReviewers(ctx, nil, pr.PullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assigned reviewers: %w", err)
+	}
+
+	return &model.ReassignResponse{
+		PR: model.PullRequestWithAssignedReviewers{
+			PullRequestID:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorID:        pr.AuthorID,
+			Status:          pr.Status,
+			Assigned:        assigned,
+		},
+		ReplacedBy:   newReviewers[0],
+		NewReviewers: newReviewers,
+	}, nil
+}
+
+func (s *PullRequestService) reassign(
+	ctx context.Context,
+	ext repository.RepoExtension,
+	pr *model.PullRequest,
+	oldReviewerID, reason string,
+) ([]string, error) {
+	if err := openStatusError(pr.Status); err != nil {
+		return nil, err
 	}
 
-	isAssigned, err := s.pullRequestRepo.IsReviewerAssigned(ctx, tx, pullRequestID, oldReviewerID)
+	isAssigned, err := s.pullRequestRepo.IsReviewerAssigned(ctx, ext, pr.PullRequestID, oldReviewerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check if reviewer is assigned: %w", err)
 	}
@@ -179,47 +403,879 @@ func (s *PullRequestService) Reassign(ctx context.Context, pullRequestID, oldRev
 		return nil, apperrors.ErrUserIsNotAssignedAsReviewer
 	}
 
-	candidates, err := s.pullRequestRepo.GetReviewerCandidates(ctx, tx, oldReviewerID, pullRequestID)
+	team, err := s.pullRequestTeam(ctx, ext, pr)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get reviewers candidates: %w", err)
+		return nil, err
+	}
+
+	current, err := s.pullRequestRepo.GetAssignedReviewers(ctx, ext, pr.PullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assigned reviewers: %w", err)
+	}
+
+	candidates, err := s.replacementCandidates(ctx, ext, pr, team, oldReviewerID, current)
+	if err != nil {
+		return nil, err
 	}
 
 	if len(candidates) == 0 {
+		recordMetric(ctx, func() { s.metrics.AssignmentFailed(assignOperationReassign) })
+
 		return nil, apperrors.ErrNoActiveReplacementCandidate
 	}
 
-	newReviewer := candidates[0]
+	needed := max(1, team.ReviewersCount-(len(current)-1))
+
+	selected, err := s.reserveReviewers(ctx, ext, s.selector.Select(team.Name, candidates, needed))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(selected) == 0 {
+		recordMetric(ctx, func() { s.metrics.AssignmentFailed(assignOperationReassign) })
 
-	if err := s.pullRequestRepo.RemoveReviewer(ctx, tx, pullRequestID, oldReviewerID); err != nil {
+		return nil, apperrors.ErrNoActiveReplacementCandidate
+	}
+
+	if err = s.pullRequestRepo.RemoveReviewer(ctx, ext, pr.PullRequestID, oldReviewerID); err != nil {
 		return nil, fmt.Errorf("failed to remove reviewer: %w", err)
 	}
 
-	if err := s.pullRequestRepo.AddReviewer(ctx, tx, pullRequestID, newReviewer); err != nil {
-		return nil, fmt.Errorf("failed to add reviewer: %w", err)
+	for _, reviewerID := range selected {
+		if err = s.pullRequestRepo.AddReviewer(ctx, ext, pr.PullRequestID, reviewerID); err != nil {
+			return nil, fmt.Errorf("failed to add reviewer: %w", err)
+		}
 	}
 
-	if err = tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	updated, err := s.pullRequestRepo.GetAssignedReviewers(ctx, ext, pr.PullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assigned reviewers: %w", err)
 	}
 
-	pr, err = s.pullRequestRepo.SelectPullRequestByID(ctx, nil, pullRequestID)
+	err = s.audit.record(ctx, ext, auditRecord{
+		action:        model.AuditReviewerReassigned,
+		pullRequestID: pr.PullRequestID,
+		userID:        oldReviewerID,
+		teamName:      team.Name,
+		before:        map[string]any{"reviewers": current},
+		after:         map[string]any{"reviewers": updated, "replaced_by": selected, "reason": reason},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.outbox.publish(ctx, ext, model.WebhookEventReviewerReassigned, model.ReviewerEventData{
+		PullRequestID: pr.PullRequestID,
+		ReviewerID:    oldReviewerID,
+		ReplacedBy:    selected,
+		Reviewers:     updated,
+		Reason:        reason,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	recordMetric(ctx, func() { s.metrics.ReviewerReassigned(reason) })
+
+	return selected, nil
+}
+
+func (s *PullRequestService) replacementCandidates(
+	ctx context.Context,
+	ext repository.RepoExtension,
+	pr *model.PullRequest,
+	team *model.Team,
+	oldReviewerID string,
+	current []string,
+) ([]model.ReviewerCandidate, error) {
+	groups, err := s.poolGroups(ctx, ext, team.ID, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.pullRequestRepo.GetReviewerCandidates(ctx, ext, oldReviewerID, pr.PullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewers candidates: %w", err)
+	}
+
+	return pickReplacementCandidates(groups, candidates, oldReviewerID, current), nil
+}
+
+func (s *PullRequestService) ReassignReviewer(
+	ctx context.Context,
+	ext repository.RepoExtension,
+	pullRequestID, reviewerID, reason string,
+) (newReviewers []string, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.ReassignReviewer")
+	defer tracing.End(span, &err)
+
+	pr, err := s.pullRequestRepo.SelectPullRequestByIDForUpdate(ctx, ext, pullRequestID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to select pull request by ID: %w", err)
 	}
 
-	assigned, err := s.pullRequestRepo.GetAssignedReviewers(ctx, nil, pr.PullRequestID)
+	return s.reassign(ctx, ext, pr, reviewerID, reason)
+}
+
+func (s *PullRequestService) AddExtraReviewer(
+	ctx context.Context,
+	ext repository.RepoExtension,
+	pullRequestID, reason string,
+) (reviewerID string, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.AddExtraReviewer")
+	defer tracing.End(span, &err)
+
+	pr, err := s.pullRequestRepo.SelectPullRequestByIDForUpdate(ctx, ext, pullRequestID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get assigned reviewers: %w", err)
+		return "", fmt.Errorf("failed to select pull request by ID: %w", err)
 	}
 
-	return &model.ReassignResponse{
-		PR: model.PullRequestWithAssignedReviewers{
-			PullRequestID:   pr.PullRequestID,
-			PullRequestName: pr.PullRequestName,
-			AuthorID:        pr.AuthorID,
-			Status:          pr.Status,
-			Assigned:        assigned,
-		},
-		ReplacedBy: newReviewer,
-	}, nil
+	if err = openStatusError(pr.Status); err != nil {
+		return "", err
+	}
+
+	team, err := s.pullRequestTeam(ctx, ext, pr)
+	if err != nil {
+		return "", err
+	}
+
+	current, err := s.pullRequestRepo.GetAssignedReviewers(ctx, ext, pr.PullRequestID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get assigned reviewers: %w", err)
+	}
+
+	candidates, err := s.pullRequestRepo.GetReviewerCandidatesForTeam(ctx, ext, team.ID, pr.AuthorID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get reviewers candidates: %w", err)
+	}
+
+	candidates = slices.DeleteFunc(candidates, func(c model.ReviewerCandidate) bool {
+		return slices.Contains(current, c.UserID)
+	})
+
+	if len(candidates) == 0 {
+		groups, err := s.poolGroups(ctx, ext, team.ID, pr.AuthorID)
+		if err != nil {
+			return "", err
+		}
+
+		candidates = fallbackCandidates(groups, current)
+	}
+
+	selected, err := s.reserveReviewers(ctx, ext, s.selector.Select(team.Name, candidates, 1))
+	if err != nil {
+		return "", err
+	}
+
+	if len(selected) == 0 {
+		recordMetric(ctx, func() { s.metrics.AssignmentFailed(assignOperationAdd) })
+
+		return "", apperrors.ErrNoActiveReplacementCandidate
+	}
+
+	if err = s.pullRequestRepo.AddReviewer(ctx, ext, pr.PullRequestID, selected[0]); err != nil {
+		return "", fmt.Errorf("failed to add reviewer: %w", err)
+	}
+
+	updated := append(slices.Clone(current), selected[0])
+
+	err = s.audit.record(ctx, ext, auditRecord{
+		action:        model.AuditReviewerAdded,
+		pullRequestID: pr.PullRequestID,
+		userID:        selected[0],
+		teamName:      team.Name,
+		before:        map[string]any{"reviewers": current},
+		after:         map[string]any{"reviewers": updated, "reason": reason},
+	})
+	if err != nil {
+		return "", err
+	}
+
+	err = s.outbox.publish(ctx, ext, model.WebhookEventReviewersAssigned, model.PullRequestEventData{
+		PullRequestID:   pr.PullRequestID,
+		PullRequestName: pr.PullRequestName,
+		AuthorID:        pr.AuthorID,
+		Status:          pr.Status,
+		TeamID:          pr.TeamID,
+		Reviewers:       updated,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return selected[0], nil
+}
+
+func (s *PullRequestService) ReassignOpenReviews(
+	ctx context.Context,
+	ext repository.RepoExtension,
+	userID string,
+	teamID *int,
+	reason string,
+) (result *model.ReviewReassignments, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.ReassignOpenReviews")
+	defer tracing.End(span, &err)
+
+	prs, err := s.pullRequestRepo.SelectPullRequestsByUserID(ctx, ext, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select user reviews: %w", err)
+	}
+
+	result = &model.ReviewReassignments{
+		Reassigned: []model.ReviewReassignment{},
+		Uncovered:  []model.ReviewReassignment{},
+	}
+
+	for _, pr := range prs {
+		if openStatusError(pr.Status) != nil {
+			continue
+		}
+
+		if teamID != nil && (pr.TeamID == nil || *pr.TeamID != *teamID) {
+			continue
+		}
+
+		newReviewers, err := s.reassign(ctx, ext, pr, userID, reason)
+
+		switch {
+		case errors.Is(err, apperrors.ErrNoActiveReplacementCandidate):
+			if err = s.pullRequestRepo.RemoveReviewer(ctx, ext, pr.PullRequestID, userID); err != nil {
+				return nil, fmt.Errorf("failed to remove reviewer: %w", err)
+			}
+
+			err = s.audit.record(ctx, ext, auditRecord{
+				action:        model.AuditReviewerRemoved,
+				pullRequestID: pr.PullRequestID,
+				userID:        userID,
+				after:         map[string]any{"reason": reason},
+			})
+			if err != nil {
+				return nil, err
+			}
+
+			reviewers, err := s.pullRequestRepo.GetAssignedReviewers(ctx, ext, pr.PullRequestID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get assigned reviewers: %w", err)
+			}
+
+			err = s.outbox.publish(ctx, ext, model.WebhookEventReviewerRemoved, model.ReviewerEventData{
+				PullRequestID: pr.PullRequestID,
+				ReviewerID:    userID,
+				Reviewers:     reviewers,
+				Reason:        reason,
+			})
+			if err != nil {
+				return nil, err
+			}
+		case err != nil:
+			return nil, fmt.Errorf("failed to reassign pull request %s: %w", pr.PullRequestID, err)
+		}
+
+		reassignment := model.ReviewReassignment{
+			PullRequestID: pr.PullRequestID,
+			OldReviewerID: userID,
+		}
+
+		if len(newReviewers) == 0 {
+			result.Uncovered = append(result.Uncovered, reassignment)
+
+			continue
+		}
+
+		reassignment.ReplacedBy = newReviewers[0]
+		reassignment.NewReviewers = newReviewers
+		result.Reassigned = append(result.Reassigned, reassignment)
+	}
+
+	return result, nil
+}
+
+func (s *PullRequestService) Ready(ctx context.Context, pullRequestID string) (response *model.PullRequestWithAssignedReviewers, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.Ready")
+	defer tracing.End(span, &err)
+
+	return s.transition(ctx, pullRequestID, prStatusOpen)
+}
+
+func (s *PullRequestService) Close(ctx context.Context, pullRequestID string) (response *model.PullRequestWithAssignedReviewers, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.Close")
+	defer tracing.End(span, &err)
+
+	return s.transition(ctx, pullRequestID, prStatusClosed)
+}
+
+func (s *PullRequestService) Reopen(ctx context.Context, pullRequestID string) (response *model.PullRequestWithAssignedReviewers, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.Reopen")
+	defer tracing.End(span, &err)
+
+	return s.transition(ctx, pullRequestID, prStatusReopened)
+}
+
+func (s *PullRequestService) transition(
+	ctx context.Context,
+	pullRequestID, status string,
+) (response *model.PullRequestWithAssignedReviewers, err error) {
+	tx, err := s.pullRequestRepo.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	ctx, pending := withPendingMetrics(ctx)
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	pr, err := s.pullRequestRepo.SelectPullRequestByIDForUpdate(ctx, tx, pullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select pull request by ID: %w", err)
+	}
+
+	if err = checkTransition(pr.Status, status); err != nil {
+		return nil, err
+	}
+
+	if err = s.pullRequestRepo.UpdatePullRequestStatus(ctx, tx, pullRequestID, pr.Status, status); err != nil {
+		return nil, fmt.Errorf("failed to update pull request status: %w", err)
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action:        model.AuditPullRequestStatus,
+		pullRequestID: pullRequestID,
+		before:        map[string]any{"status": pr.Status},
+		after:         map[string]any{"status": status},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pr.Status = status
+
+	reviewers, err := s.pullRequestRepo.GetAssignedReviewers(ctx, tx, pullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assigned reviewers: %w", err)
+	}
+
+	assignment := &reviewerAssignment{reviewers: reviewers}
+
+	if status != prStatusClosed && len(reviewers) == 0 {
+		assignment, err = s.assignReviewers(ctx, tx, pr)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	reviews, err := s.pullRequestRepo.GetLatestReviews(ctx, tx, pullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviews: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	pending.flush()
+
+	return &model.PullRequestWithAssignedReviewers{
+		PullRequestID:    pr.PullRequestID,
+		PullRequestName:  pr.PullRequestName,
+		AuthorID:         pr.AuthorID,
+		Status:           pr.Status,
+		Assigned:         assignment.reviewers,
+		Reviews:          reviews,
+		OwnerReviewers:   assignment.owners,
+		PoolReviewers:    assignment.pool,
+		MissingReviewers: assignment.missing,
+	}, nil
+}
+
+func (s *PullRequestService) Review(
+	ctx context.Context,
+	pullRequestID, reviewerID, verdict, comment string,
+) (response *model.PullRequestWithAssignedReviewers, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.Review")
+	defer tracing.End(span, &err)
+
+	tx, err := s.pullRequestRepo.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	pr, err := s.pullRequestRepo.SelectPullRequestByIDForUpdate(ctx, tx, pullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select pull request by ID: %w", err)
+	}
+
+	if err = openStatusError(pr.Status); err != nil {
+		return nil, err
+	}
+
+	if _, err = s.userRepo.SelectUserByID(ctx, tx, reviewerID); err != nil {
+		return nil, fmt.Errorf("failed to select reviewer: %w", err)
+	}
+
+	isAssigned, err := s.pullRequestRepo.IsReviewerAssigned(ctx, tx, pullRequestID, reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check if reviewer is assigned: %w", err)
+	}
+
+	if !isAssigned {
+		return nil, apperrors.ErrUserIsNotAssignedAsReviewer
+	}
+
+	if _, err = s.pullRequestRepo.InsertReview(ctx, tx, pullRequestID, reviewerID, verdict, comment); err != nil {
+		return nil, fmt.Errorf("failed to insert review: %w", err)
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action:        model.AuditReviewSubmitted,
+		pullRequestID: pullRequestID,
+		userID:        reviewerID,
+		after:         map[string]any{"verdict": verdict, "comment": comment},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	reviewers, err := s.pullRequestRepo.GetAssignedReviewers(ctx, tx, pullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assigned reviewers: %w", err)
+	}
+
+	reviews, err := s.pullRequestRepo.GetLatestReviews(ctx, tx, pullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviews: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &model.PullRequestWithAssignedReviewers{
+		PullRequestID:   pr.PullRequestID,
+		PullRequestName: pr.PullRequestName,
+		AuthorID:        pr.AuthorID,
+		Status:          pr.Status,
+		Assigned:        reviewers,
+		Reviews:         reviews,
+	}, nil
+}
+
+func (s *PullRequestService) checkApprovals(ctx context.Context, ext repository.RepoExtension, pr *model.PullRequest) error {
+	team, err := s.pullRequestTeam(ctx, ext, pr)
+	if err != nil {
+		return err
+	}
+
+	if team.RequiredApprovals == 0 {
+		return nil
+	}
+
+	approvals, err := s.pullRequestRepo.CountApprovals(ctx, ext, pr.PullRequestID)
+	if err != nil {
+		return fmt.Errorf("failed to count approvals: %w", err)
+	}
+
+	if approvals < team.RequiredApprovals {
+		return fmt.Errorf("%w: %d of %d", apperrors.ErrNotEnoughApprovals, approvals, team.RequiredApprovals)
+	}
+
+	return nil
+}
+
+type reviewerAssignment struct {
+	reviewers []string
+	owners    []string
+	pool      []string
+	missing   int
+}
+
+// assignReviewers counts as missing empty slots, required owner rules and "always" pools nobody could cover.
+func (s *PullRequestService) assignReviewers(ctx context.Context, ext repository.RepoExtension, pr *model.PullRequest) (*reviewerAssignment, error) {
+	team, err := s.pullRequestTeam(ctx, ext, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	owners, unsatisfied, err := s.pickCodeOwners(ctx, ext, pr, team)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.pullRequestRepo.GetReviewerCandidatesForTeam(ctx, ext, team.ID, pr.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviewers candidates: %w", err)
+	}
+
+	candidates = slices.DeleteFunc(candidates, func(c model.ReviewerCandidate) bool {
+		return slices.Contains(owners, c.UserID)
+	})
+
+	reviewers := append(slices.Clone(owners), s.selector.Select(team.Name, candidates, team.ReviewersCount-len(owners))...)
+
+	groups, err := s.poolGroups(ctx, ext, team.ID, pr.AuthorID)
+	if err != nil {
+		return nil, err
+	}
+
+	fromPools, missing := pickPoolReviewers(s.selector, team.Name, groups, reviewers, team.ReviewersCount)
+	reviewers = append(reviewers, fromPools...)
+	missing += unsatisfied
+
+	reserved, err := s.reserveReviewers(ctx, ext, reviewers)
+	if err != nil {
+		return nil, err
+	}
+
+	missing += len(reviewers) - len(reserved)
+	reviewers = reserved
+
+	dropped := func(id string) bool { return !slices.Contains(reserved, id) }
+	owners = slices.DeleteFunc(owners, dropped)
+	fromPools = slices.DeleteFunc(fromPools, dropped)
+
+	if missing > 0 {
+		recordMetric(ctx, func() { s.metrics.AssignmentFailed(assignOperationAssign) })
+	}
+
+	rIDs, err := s.pullRequestRepo.SetReviewers(ctx, ext, pr.PullRequestID, reviewers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set reviewers: %w", err)
+	}
+
+	err = s.audit.record(ctx, ext, auditRecord{
+		action:        model.AuditReviewersAssigned,
+		pullRequestID: pr.PullRequestID,
+		teamName:      team.Name,
+		after: map[string]any{
+			"reviewers":         rIDs,
+			"owner_reviewers":   owners,
+			"pool_reviewers":    fromPools,
+			"missing_reviewers": missing,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rIDs) > 0 {
+		err = s.outbox.publish(ctx, ext, model.WebhookEventReviewersAssigned, model.PullRequestEventData{
+			PullRequestID:   pr.PullRequestID,
+			PullRequestName: pr.PullRequestName,
+			AuthorID:        pr.AuthorID,
+			Status:          pr.Status,
+			TeamID:          pr.TeamID,
+			Reviewers:       rIDs,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &reviewerAssignment{
+		reviewers: rIDs,
+		owners:    owners,
+		pool:      fromPools,
+		missing:   missing,
+	}, nil
+}
+
+type poolGroup struct {
+	pool       model.TeamReviewerPool
+	candidates []model.ReviewerCandidate
+}
+
+func (s *PullRequestService) poolGroups(ctx context.Context, ext repository.RepoExtension, teamID int, authorID string) ([]poolGroup, error) {
+	pools, err := s.poolRepo.SelectTeamReviewerPools(ctx, ext, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select team reviewer pools: %w", err)
+	}
+
+	groups := make([]poolGroup, 0, len(pools))
+
+	for _, pool := range pools {
+		group := poolGroup{pool: pool}
+
+		if len(pool.Members) > 0 {
+			group.candidates, err = s.pullRequestRepo.GetCandidatesAmong(ctx, ext, pool.Members, nil, authorID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get reviewer pool candidates: %w", err)
+			}
+		}
+
+		groups = append(groups, group)
+	}
+
+	return groups, nil
+}
+
+// pickPoolReviewers fills the slots from fallback pools in attach order, then adds one per unrepresented "always" pool.
+func pickPoolReviewers(
+	selector ReviewerSelector,
+	teamName string,
+	groups []poolGroup,
+	chosen []string,
+	count int,
+) (picked []string, missing int) {
+	all := slices.Clone(chosen)
+
+	for _, group := range groups {
+		need := count - len(all)
+		if group.pool.Mode != model.PoolModeFallback || need <= 0 {
+			continue
+		}
+
+		ids := selector.Select(teamName, excludeCandidates(group.candidates, all), need)
+		all = append(all, ids...)
+		picked = append(picked, ids...)
+	}
+
+	missing = max(0, count-len(all))
+
+	for _, group := range groups {
+		if group.pool.Mode != model.PoolModeAlways || poolCovered(group.pool, all) {
+			continue
+		}
+
+		ids := selector.Select(teamName, excludeCandidates(group.candidates, all), 1)
+		if len(ids) == 0 {
+			missing++
+		}
+
+		all = append(all, ids...)
+		picked = append(picked, ids...)
+	}
+
+	return picked, missing
+}
+
+// pickReplacementCandidates replaces the only member of an "always" pool on the PR from that pool, anyone else
+// from the team or, when nobody there is available, from the first fallback pool with someone.
+func pickReplacementCandidates(
+	groups []poolGroup,
+	team []model.ReviewerCandidate,
+	oldReviewerID string,
+	current []string,
+) []model.ReviewerCandidate {
+	others := slices.DeleteFunc(slices.Clone(current), func(id string) bool { return id == oldReviewerID })
+
+	for _, group := range groups {
+		if group.pool.Mode != model.PoolModeAlways || !slices.Contains(group.pool.Members, oldReviewerID) || poolCovered(group.pool, others) {
+			continue
+		}
+
+		if candidates := excludeCandidates(group.candidates, current); len(candidates) > 0 {
+			return candidates
+		}
+	}
+
+	if len(team) > 0 {
+		return team
+	}
+
+	return fallbackCandidates(groups, current)
+}
+
+func fallbackCandidates(groups []poolGroup, exclude []string) []model.ReviewerCandidate {
+	for _, group := range groups {
+		if group.pool.Mode != model.PoolModeFallback {
+			continue
+		}
+
+		if candidates := excludeCandidates(group.candidates, exclude); len(candidates) > 0 {
+			return candidates
+		}
+	}
+
+	return nil
+}
+
+func excludeCandidates(candidates []model.ReviewerCandidate, exclude []string) []model.ReviewerCandidate {
+	return slices.DeleteFunc(slices.Clone(candidates), func(c model.ReviewerCandidate) bool {
+		return slices.Contains(exclude, c.UserID)
+	})
+}
+
+// reserveReviewers drops reviewers taken by a concurrent assignment and keeps the rest in order.
+func (s *PullRequestService) reserveReviewers(ctx context.Context, ext repository.RepoExtension, picked []string) ([]string, error) {
+	if len(picked) == 0 {
+		return picked, nil
+	}
+
+	free, err := s.pullRequestRepo.LockReviewersBelowCapacity(ctx, ext, picked)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock reviewers: %w", err)
+	}
+
+	return slices.DeleteFunc(slices.Clone(picked), func(id string) bool {
+		return !slices.Contains(free, id)
+	}), nil
+}
+
+func poolCovered(pool model.TeamReviewerPool, reviewers []string) bool {
+	return slices.ContainsFunc(reviewers, func(id string) bool {
+		return slices.Contains(pool.Members, id)
+	})
+}
+
+func (s *PullRequestService) pickCodeOwners(
+	ctx context.Context,
+	ext repository.RepoExtension,
+	pr *model.PullRequest,
+	team *model.Team,
+) (owners []string, unsatisfied int, err error) {
+	if len(pr.ChangedPaths) == 0 {
+		return nil, 0, nil
+	}
+
+	rules, err := s.codeOwnerRepo.SelectCodeOwnerRules(ctx, ext, team.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to select code owner rules: %w", err)
+	}
+
+	matches, _ := matchCodeOwners(rules, pr.ChangedPaths)
+
+	groups := make([]ownerGroup, 0, len(matches))
+
+	for _, match := range matches {
+		candidates, err := s.pullRequestRepo.GetCandidatesAmong(ctx, ext, match.Rule.Users, match.Rule.Teams, pr.AuthorID)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get code owner candidates: %w", err)
+		}
+
+		groups = append(groups, ownerGroup{
+			required:   match.Rule.Required,
+			candidates: candidates,
+		})
+	}
+
+	owners, unsatisfied = pickOwners(groups, team.ReviewersCount)
+
+	return owners, unsatisfied, nil
+}
+
+func (s *PullRequestService) Get(ctx context.Context, pullRequestID string) (response *model.PullRequest, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.Get")
+	defer tracing.End(span, &err)
+
+	pr, err := s.pullRequestRepo.SelectPullRequestByID(ctx, nil, pullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select pull request by ID: %w", err)
+	}
+
+	pr.Assigned, err = s.pullRequestRepo.GetAssignedReviewers(ctx, nil, pullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get assigned reviewers: %w", err)
+	}
+
+	pr.Reviews, err = s.pullRequestRepo.GetLatestReviews(ctx, nil, pullRequestID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviews: %w", err)
+	}
+
+	return pr, nil
+}
+
+func (s *PullRequestService) List(ctx context.Context, qp *model.PullRequestListQueryParam) (response *model.PullRequestListResponse, err error) {
+	ctx, span := tracing.Start(ctx, "PullRequestService.List")
+	defer tracing.End(span, &err)
+
+	limit := qp.Limit
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+
+	filter := &model.PullRequestFilter{
+		AuthorID:    qp.AuthorID,
+		ReviewerID:  qp.ReviewerID,
+		TeamName:    qp.TeamName,
+		Status:      qp.Status,
+		CreatedFrom: qp.CreatedFrom,
+		CreatedTo:   qp.CreatedTo,
+		MergedFrom:  qp.MergedFrom,
+		MergedTo:    qp.MergedTo,
+		Limit:       limit + 1,
+	}
+
+	if qp.Cursor != "" {
+		afterTime, afterID, err := decodeCursor(qp.Cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		filter.AfterTime = &afterTime
+		filter.AfterID = afterID
+	}
+
+	prs, err := s.pullRequestRepo.SelectPullRequests(ctx, nil, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select pull requests: %w", err)
+	}
+
+	response = &model.PullRequestListResponse{
+		PullRequests: prs,
+	}
+
+	if len(prs) > limit {
+		response.PullRequests = prs[:limit]
+
+		last := response.PullRequests[limit-1]
+		if last.CreatedAt != nil {
+			response.NextCursor = encodeCursor(*last.CreatedAt, last.PullRequestID)
+		}
+	}
+
+	return response, nil
+}
+
+func (s *PullRequestService) resolveTeam(ctx context.Context, ext repository.RepoExtension, userID string, teamID *int) (*model.Team, error) {
+	teams, err := s.teamRepo.SelectTeamsByUserID(ctx, ext, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select user teams: %w", err)
+	}
+
+	if len(teams) == 0 {
+		return nil, apperrors.ErrTeamNotExist
+	}
+
+	if teamID != nil {
+		for i := range teams {
+			if teams[i].ID == *teamID {
+				return &teams[i], nil
+			}
+		}
+
+		return nil, apperrors.ErrUserNotInTeam
+	}
+
+	if len(teams) > 1 {
+		return nil, apperrors.ErrTeamAmbiguous
+	}
+
+	return &teams[0], nil
+}
+
+func (s *PullRequestService) pullRequestTeam(ctx context.Context, ext repository.RepoExtension, pr *model.PullRequest) (*model.Team, error) {
+	if pr.TeamID == nil {
+		return s.resolveTeam(ctx, ext, pr.AuthorID, nil)
+	}
+
+	team, err := s.teamRepo.SelectTeamByID(ctx, ext, *pr.TeamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select pull request team: %w", err)
+	}
+
+	return team, nil
 }
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"avito-test-assignment/internal/model"
+	"avito-test-assignment/internal/repository"
+)
+
+type fakePullRequestRepo struct {
+	PullRequestRepositoryForPR
+
+	free   []string
+	locked [][]string
+}
+
+func (r *fakePullRequestRepo) LockReviewersBelowCapacity(_ context.Context, _ repository.RepoExtension, userIDs []string) ([]string, error) {
+	r.locked = append(r.locked, userIDs)
+
+	return slices.DeleteFunc(slices.Clone(userIDs), func(id string) bool {
+		return !slices.Contains(r.free, id)
+	}), nil
+}
+
+func TestReserveReviewers(t *testing.T) {
+	tests := []struct {
+		name   string
+		picked []string
+		free   []string
+		want   []string
+	}{
+		{"all free", []string{"u3", "u1"}, []string{"u1", "u3"}, []string{"u3", "u1"}},
+		{"taken concurrently", []string{"u1", "u2", "u3"}, []string{"u3", "u1"}, []string{"u1", "u3"}},
+		{"none free", []string{"u1"}, nil, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakePullRequestRepo{free: tt.free}
+			s := &PullRequestService{pullRequestRepo: repo}
+
+			got, err := s.reserveReviewers(context.Background(), nil, tt.picked)
+			if err != nil {
+				t.Fatalf("reserveReviewers: %v", err)
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+
+			if len(repo.locked) != 1 || !slices.Equal(repo.locked[0], tt.picked) {
+				t.Fatalf("locked %v, want %v", repo.locked, tt.picked)
+			}
+		})
+	}
+}
+
+func TestReserveReviewersSkipsEmptyPick(t *testing.T) {
+	repo := &fakePullRequestRepo{}
+	s := &PullRequestService{pullRequestRepo: repo}
+
+	if got, err := s.reserveReviewers(context.Background(), nil, nil); err != nil || len(got) != 0 {
+		t.Fatalf("got %v, %v", got, err)
+	}
+
+	if len(repo.locked) != 0 {
+		t.Fatal("nothing should be locked for an empty pick")
+	}
+}
+
+func poolOf(mode string, load map[string]int) poolGroup {
+	group := poolGroup{pool: model.TeamReviewerPool{Mode: mode}, candidates: candidates(load)}
+	for _, c := range group.candidates {
+		group.pool.Members = append(group.pool.Members, c.UserID)
+	}
+
+	slices.Sort(group.pool.Members)
+
+	return group
+}
+
+func TestPickPoolReviewers(t *testing.T) {
+	tests := []struct {
+		name        string
+		groups      []poolGroup
+		chosen      []string
+		count       int
+		wantPicked  []string
+		wantMissing int
+	}{
+		{
+			name:   "team already full",
+			groups: []poolGroup{poolOf(model.PoolModeFallback, map[string]int{"f1": 0})},
+			chosen: []string{"u1", "u2"},
+			count:  2,
+		},
+		{
+			name: "fallback pools fill in order",
+			groups: []poolGroup{
+				poolOf(model.PoolModeFallback, map[string]int{"f1": 2}),
+				poolOf(model.PoolModeFallback, map[string]int{"g1": 0, "g2": 1}),
+			},
+			chosen:     []string{"u1"},
+			count:      3,
+			wantPicked: []string{"f1", "g1"},
+		},
+		{
+			name:        "fallback pools run dry",
+			groups:      []poolGroup{poolOf(model.PoolModeFallback, map[string]int{"u1": 0})},
+			chosen:      []string{"u1"},
+			count:       3,
+			wantMissing: 2,
+		},
+		{
+			name:       "always pool tops up a full team",
+			groups:     []poolGroup{poolOf(model.PoolModeAlways, map[string]int{"s1": 3, "s2": 1})},
+			chosen:     []string{"u1", "u2"},
+			count:      2,
+			wantPicked: []string{"s2"},
+		},
+		{
+			name:   "always pool already represented",
+			groups: []poolGroup{poolOf(model.PoolModeAlways, map[string]int{"u2": 0, "s1": 0})},
+			chosen: []string{"u1", "u2"},
+			count:  2,
+		},
+		{
+			name: "always pool covered by a fallback pick",
+			groups: []poolGroup{
+				poolOf(model.PoolModeAlways, map[string]int{"s1": 0, "f1": 1}),
+				poolOf(model.PoolModeFallback, map[string]int{"f1": 1}),
+			},
+			chosen:     []string{"u1"},
+			count:      2,
+			wantPicked: []string{"f1"},
+		},
+		{
+			name:        "always pool with nobody available",
+			groups:      []poolGroup{poolOf(model.PoolModeAlways, nil)},
+			chosen:      []string{"u1", "u2"},
+			count:       2,
+			wantMissing: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			picked, missing := pickPoolReviewers(LeastLoadedSelector{}, "backend", tt.groups, tt.chosen, tt.count)
+			if !slices.Equal(picked, tt.wantPicked) || missing != tt.wantMissing {
+				t.Fatalf("got %v missing %d, want %v missing %d", picked, missing, tt.wantPicked, tt.wantMissing)
+			}
+		})
+	}
+}
+
+type recordingSelector struct {
+	teams []string
+}
+
+func (s *recordingSelector) Select(teamName string, candidates []model.ReviewerCandidate, count int) []string {
+	s.teams = append(s.teams, teamName)
+
+	return LeastLoadedSelector{}.Select(teamName, candidates, count)
+}
+
+func TestPickPoolReviewersUsesSelector(t *testing.T) {
+	selector := &recordingSelector{}
+	groups := []poolGroup{
+		poolOf(model.PoolModeFallback, map[string]int{"f1": 0}),
+		poolOf(model.PoolModeAlways, map[string]int{"s1": 0}),
+	}
+
+	pickPoolReviewers(selector, "backend", groups, nil, 1)
+
+	if !slices.Equal(selector.teams, []string{"backend", "backend"}) {
+		t.Fatalf("selector called for %v, want both pools picked through it", selector.teams)
+	}
+}
+
+func TestPickReplacementCandidates(t *testing.T) {
+	team := candidates(map[string]int{"t1": 0})
+	always := poolOf(model.PoolModeAlways, map[string]int{"s1": 0, "s2": 1})
+	fallback := poolOf(model.PoolModeFallback, map[string]int{"f1": 0, "u2": 0})
+
+	tests := []struct {
+		name    string
+		groups  []poolGroup
+		team    []model.ReviewerCandidate
+		old     string
+		current []string
+		want    []string
+	}{
+		{"team member replaced from team", []poolGroup{always, fallback}, team, "u1", []string{"u1", "s1"}, []string{"t1"}},
+		{"sole pool member replaced from pool", []poolGroup{always, fallback}, team, "s1", []string{"u1", "s1"}, []string{"s2"}},
+		{"pool still covered by another reviewer", []poolGroup{always}, team, "s1", []string{"s1", "s2"}, []string{"t1"}},
+		{"empty pool falls back to team", []poolGroup{poolOf(model.PoolModeAlways, map[string]int{"s1": 0})}, team, "s1", []string{"s1"}, []string{"t1"}},
+		{"empty team uses fallback pool", []poolGroup{always, fallback}, nil, "u1", []string{"u1", "u2"}, []string{"f1"}},
+		{"nobody anywhere", []poolGroup{fallback}, nil, "u1", []string{"u1", "f1", "u2"}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := pickReplacementCandidates(tt.groups, tt.team, tt.old, tt.current)
+
+			var ids []string
+			for _, c := range got {
+				ids = append(ids, c.UserID)
+			}
+
+			slices.Sort(ids)
+
+			if !slices.Equal(ids, tt.want) {
+				t.Fatalf("got %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,331 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"avito-test-assignment/internal/model"
+	"avito-test-assignment/internal/repository"
+)
+
+type ReviewerPoolRepositoryForPool interface {
+	Pool() *pgxpool.Pool
+
+	InsertReviewerPool(ctx context.Context, ext repository.RepoExtension, poolName string) (int, error)
+	SelectReviewerPoolByName(ctx context.Context, ext repository.RepoExtension, poolName string) (*model.ReviewerPool, error)
+	SelectReviewerPools(ctx context.Context, ext repository.RepoExtension) ([]model.ReviewerPool, error)
+	DeleteReviewerPool(ctx context.Context, ext repository.RepoExtension, poolID int) error
+	InsertPoolMembers(ctx context.Context, ext repository.RepoExtension, poolID int, userIDs []string) error
+	DeletePoolMembers(ctx context.Context, ext repository.RepoExtension, poolID int, userIDs []string) error
+	UpsertTeamReviewerPool(ctx context.Context, ext repository.RepoExtension, teamID, poolID int, mode string) error
+	DeleteTeamReviewerPool(ctx context.Context, ext repository.RepoExtension, teamID, poolID int) error
+	SelectTeamReviewerPools(ctx context.Context, ext repository.RepoExtension, teamID int) ([]model.TeamReviewerPool, error)
+}
+
+type TeamRepositoryForPool interface {
+	SelectTeamByName(ctx context.Context, ext repository.RepoExtension, teamName string) (*model.Team, error)
+}
+
+type UserRepositoryForPool interface {
+	SelectUserByID(ctx context.Context, ext repository.RepoExtension, userID string) (*model.User, error)
+}
+
+type ReviewerPoolService struct {
+	poolRepo ReviewerPoolRepositoryForPool
+	teamRepo TeamRepositoryForPool
+	userRepo UserRepositoryForPool
+	audit    auditWriter
+}
+
+func NewReviewerPoolService(
+	poolRepo ReviewerPoolRepositoryForPool,
+	teamRepo TeamRepositoryForPool,
+	userRepo UserRepositoryForPool,
+	auditRepo AuditRepositoryForWrite,
+) *ReviewerPoolService {
+	return &ReviewerPoolService{
+		poolRepo: poolRepo,
+		teamRepo: teamRepo,
+		userRepo: userRepo,
+		audit:    auditWriter{repo: auditRepo},
+	}
+}
+
+func (s *ReviewerPoolService) Create(ctx context.Context, poolName string, members []string) (pool *model.ReviewerPool, err error) {
+	tx, err := s.poolRepo.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	poolID, err := s.poolRepo.InsertReviewerPool(ctx, tx, poolName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert reviewer pool: %w", err)
+	}
+
+	members = compactStrings(members)
+
+	if err = s.checkUsers(ctx, tx, members); err != nil {
+		return nil, err
+	}
+
+	if err = s.poolRepo.InsertPoolMembers(ctx, tx, poolID, members); err != nil {
+		return nil, fmt.Errorf("failed to insert pool members: %w", err)
+	}
+
+	pool, err = s.poolRepo.SelectReviewerPoolByName(ctx, tx, poolName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviewer pool: %w", err)
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action: model.AuditReviewerPoolCreated,
+		after:  map[string]any{"pool_name": pool.Name, "members": pool.Members},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return pool, nil
+}
+
+func (s *ReviewerPoolService) Get(ctx context.Context, poolName string) (*model.ReviewerPool, error) {
+	pool, err := s.poolRepo.SelectReviewerPoolByName(ctx, nil, poolName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviewer pool: %w", err)
+	}
+
+	return pool, nil
+}
+
+func (s *ReviewerPoolService) List(ctx context.Context) (*model.ReviewerPoolListResponse, error) {
+	pools, err := s.poolRepo.SelectReviewerPools(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviewer pools: %w", err)
+	}
+
+	return &model.ReviewerPoolListResponse{Pools: pools}, nil
+}
+
+func (s *ReviewerPoolService) Delete(ctx context.Context, poolName string) (err error) {
+	tx, err := s.poolRepo.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	pool, err := s.poolRepo.SelectReviewerPoolByName(ctx, tx, poolName)
+	if err != nil {
+		return fmt.Errorf("failed to select reviewer pool: %w", err)
+	}
+
+	if err = s.poolRepo.DeleteReviewerPool(ctx, tx, pool.ID); err != nil {
+		return fmt.Errorf("failed to delete reviewer pool: %w", err)
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action: model.AuditReviewerPoolDeleted,
+		before: pool,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ReviewerPoolService) AddMembers(ctx context.Context, poolName string, userIDs []string) (*model.ReviewerPool, error) {
+	return s.changeMembers(ctx, poolName, userIDs, true)
+}
+
+func (s *ReviewerPoolService) RemoveMembers(ctx context.Context, poolName string, userIDs []string) (*model.ReviewerPool, error) {
+	return s.changeMembers(ctx, poolName, userIDs, false)
+}
+
+func (s *ReviewerPoolService) changeMembers(
+	ctx context.Context,
+	poolName string,
+	userIDs []string,
+	add bool,
+) (pool *model.ReviewerPool, err error) {
+	tx, err := s.poolRepo.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	prev, err := s.poolRepo.SelectReviewerPoolByName(ctx, tx, poolName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviewer pool: %w", err)
+	}
+
+	userIDs = compactStrings(userIDs)
+
+	if add {
+		if err = s.checkUsers(ctx, tx, userIDs); err != nil {
+			return nil, err
+		}
+
+		err = s.poolRepo.InsertPoolMembers(ctx, tx, prev.ID, userIDs)
+	} else {
+		err = s.poolRepo.DeletePoolMembers(ctx, tx, prev.ID, userIDs)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to update pool members: %w", err)
+	}
+
+	pool, err = s.poolRepo.SelectReviewerPoolByName(ctx, tx, poolName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviewer pool: %w", err)
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action: model.AuditReviewerPoolChanged,
+		before: map[string]any{"pool_name": prev.Name, "members": prev.Members},
+		after:  map[string]any{"pool_name": pool.Name, "members": pool.Members},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return pool, nil
+}
+
+func (s *ReviewerPoolService) Attach(ctx context.Context, teamName, poolName, mode string) (*model.ReviewerPool, error) {
+	return s.changeAttachment(ctx, teamName, poolName, mode)
+}
+
+func (s *ReviewerPoolService) Detach(ctx context.Context, teamName, poolName string) (*model.ReviewerPool, error) {
+	return s.changeAttachment(ctx, teamName, poolName, "")
+}
+
+// changeAttachment detaches the pool when mode is empty.
+func (s *ReviewerPoolService) changeAttachment(
+	ctx context.Context,
+	teamName, poolName, mode string,
+) (pool *model.ReviewerPool, err error) {
+	tx, err := s.poolRepo.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	team, err := s.teamRepo.SelectTeamByName(ctx, tx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select team: %w", err)
+	}
+
+	pool, err = s.poolRepo.SelectReviewerPoolByName(ctx, tx, poolName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviewer pool: %w", err)
+	}
+
+	before, err := s.teamPools(ctx, tx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if mode == "" {
+		err = s.poolRepo.DeleteTeamReviewerPool(ctx, tx, team.ID, pool.ID)
+	} else {
+		err = s.poolRepo.UpsertTeamReviewerPool(ctx, tx, team.ID, pool.ID, mode)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to update team reviewer pool: %w", err)
+	}
+
+	after, err := s.teamPools(ctx, tx, team.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action:   model.AuditTeamSettingsChanged,
+		teamName: team.Name,
+		before:   map[string]any{"reviewer_pools": before},
+		after:    map[string]any{"reviewer_pools": after},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pool, err = s.poolRepo.SelectReviewerPoolByName(ctx, tx, poolName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select reviewer pool: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return pool, nil
+}
+
+func (s *ReviewerPoolService) teamPools(ctx context.Context, ext repository.RepoExtension, teamID int) (map[string]string, error) {
+	pools, err := s.poolRepo.SelectTeamReviewerPools(ctx, ext, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select team reviewer pools: %w", err)
+	}
+
+	modes := make(map[string]string, len(pools))
+
+	for _, pool := range pools {
+		modes[pool.PoolName] = pool.Mode
+	}
+
+	return modes, nil
+}
+
+func (s *ReviewerPoolService) checkUsers(ctx context.Context, ext repository.RepoExtension, userIDs []string) error {
+	for _, userID := range userIDs {
+		if _, err := s.userRepo.SelectUserByID(ctx, ext, userID); err != nil {
+			return fmt.Errorf("failed to select user: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// inSavepoint runs fn in a savepoint of tx. failed is the error of fn, whose writes and metrics
+// are then dropped while tx stays usable; err means tx itself can no longer be used.
+func inSavepoint(ctx context.Context, tx pgx.Tx, fn func(ctx context.Context, sp pgx.Tx) error) (failed, err error) {
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create savepoint: %w", err)
+	}
+
+	spCtx, pending := withPendingMetrics(ctx)
+
+	if failed = fn(spCtx, sp); failed != nil {
+		if err = sp.Rollback(ctx); err != nil {
+			return failed, fmt.Errorf("failed to rollback to savepoint: %w", err)
+		}
+
+		return failed, nil
+	}
+
+	if err = sp.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to release savepoint: %w", err)
+	}
+
+	recordMetric(ctx, pending.flush)
+
+	return nil, nil
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"avito-test-assignment/internal/model"
+)
+
+const (
+	StrategyLeastLoaded = "least_loaded"
+	StrategyRoundRobin  = "round_robin"
+	StrategyRandom      = "random"
+	StrategyWeighted    = "weighted"
+
+	defaultReviewerWeight = 1
+)
+
+var ErrUnknownSelectionStrategy = errors.New("unknown reviewer selection strategy")
+
+type ReviewerSelector interface {
+	Select(teamName string, candidates []model.ReviewerCandidate, count int) []string
+}
+
+type SelectorConfig struct {
+	Strategy string
+	Seed     uint64
+	Weights  map[string]int
+	Teams    map[string]string
+}
+
+func NewReviewerSelector(cfg *SelectorConfig) (ReviewerSelector, error) {
+	fallback, err := newStrategy(cfg.Strategy, cfg.Seed, cfg.Weights)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Teams) == 0 {
+		return fallback, nil
+	}
+
+	teams := make(map[string]ReviewerSelector, len(cfg.Teams))
+
+	for teamName, strategy := range cfg.Teams {
+		selector, err := newStrategy(strategy, cfg.Seed, cfg.Weights)
+		if err != nil {
+			return nil, fmt.Errorf("team %s: %w", teamName, err)
+		}
+
+		teams[teamName] = selector
+	}
+
+	return &teamSelector{fallback: fallback, teams: teams}, nil
+}
+
+func newStrategy(name string, seed uint64, weights map[string]int) (ReviewerSelector, error) {
+	switch strings.ToLower(name) {
+	case "", StrategyLeastLoaded:
+		return LeastLoadedSelector{}, nil
+	case StrategyRoundRobin:
+		return NewRoundRobinSelector(), nil
+	case StrategyRandom:
+		return NewRandomSelector(seed), nil
+	case StrategyWeighted:
+		return NewWeightedSelector(seed, weights), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSelectionStrategy, name)
+	}
+}
+
+type teamSelector struct {
+	fallback ReviewerSelector
+	teams    map[string]ReviewerSelector
+}
+
+func (s *teamSelector) Select(teamName string, candidates []model.ReviewerCandidate, count int) []string {
+	if selector, ok := s.teams[teamName]; ok {
+		return selector.Select(teamName, candidates, count)
+	}
+
+	return s.fallback.Select(teamName, candidates, count)
+}
+
+type LeastLoadedSelector struct{}
+
+func (LeastLoadedSelector) Select(_ string, candidates []model.ReviewerCandidate, count int) []string {
+	return takeIDs(sortCandidates(candidates), count)
+}
+
+func sortCandidates(candidates []model.ReviewerCandidate) []model.ReviewerCandidate {
+	sorted := slices.Clone(candidates)
+
+	slices.SortStableFunc(sorted, func(a, b model.ReviewerCandidate) int {
+		if a.OpenReviews != b.OpenReviews {
+			return a.OpenReviews - b.OpenReviews
+		}
+
+		return strings.Compare(a.UserID, b.UserID)
+	})
+
+	return sorted
+}
+
+type RoundRobinSelector struct {
+	mu      sync.Mutex
+	cursors map[string]int
+}
+
+func NewRoundRobinSelector() *RoundRobinSelector {
+	return &RoundRobinSelector{cursors: make(map[string]int)}
+}
+
+func (s *RoundRobinSelector) Select(teamName string, candidates []model.ReviewerCandidate, count int) []string {
+	if len(candidates) == 0 || count <= 0 {
+		return []string{}
+	}
+
+	ids := make([]string, 0, len(candidates))
+	for _, c := range candidates {
+		ids = append(ids, c.UserID)
+	}
+
+	slices.Sort(ids)
+
+	count = min(count, len(ids))
+
+	s.mu.Lock()
+	start := s.cursors[teamName] % len(ids)
+	s.cursors[teamName] = start + count
+	s.mu.Unlock()
+
+	selected := make([]string, 0, count)
+
+	for i := range count {
+		selected = append(selected, ids[(start+i)%len(ids)])
+	}
+
+	return selected
+}
+
+// RandomSelector seeds from the current time when seed is zero.
+type RandomSelector struct {
+	mu  sync.Mutex
+	rnd *rand.Rand
+}
+
+func NewRandomSelector(seed uint64) *RandomSelector {
+	return &RandomSelector{rnd: newRand(seed)}
+}
+
+func (s *RandomSelector) Select(_ string, candidates []model.ReviewerCandidate, count int) []string {
+	shuffled := slices.Clone(candidates)
+
+	s.mu.Lock()
+	s.rnd.Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	s.mu.Unlock()
+
+	return takeIDs(shuffled, count)
+}
+
+type WeightedSelector struct {
+	mu      sync.Mutex
+	rnd     *rand.Rand
+	weights map[string]int
+}
+
+func NewWeightedSelector(seed uint64, weights map[string]int) *WeightedSelector {
+	return &WeightedSelector{rnd: newRand(seed), weights: weights}
+}
+
+func (s *WeightedSelector) Select(_ string, candidates []model.ReviewerCandidate, count int) []string {
+	pool := make([]model.ReviewerCandidate, 0, len(candidates))
+	total := 0
+
+	for _, c := range candidates {
+		if w := s.weight(c.UserID); w > 0 {
+			pool = append(pool, c)
+			total += w
+		}
+	}
+
+	selected := make([]string, 0, max(0, min(count, len(pool))))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for len(selected) < count && len(pool) > 0 {
+		point := s.rnd.IntN(total)
+
+		for i, c := range pool {
+			w := s.weight(c.UserID)
+			if point < w {
+				selected = append(selected, c.UserID)
+				total -= w
+				pool = slices.Delete(pool, i, i+1)
+
+				break
+			}
+
+			point -= w
+		}
+	}
+
+	return selected
+}
+
+func (s *WeightedSelector) weight(userID string) int {
+	if w, ok := s.weights[userID]; ok {
+		return w
+	}
+
+	return defaultReviewerWeight
+}
+
+func newRand(seed uint64) *rand.Rand {
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano()) //nolint:gosec
+	}
+
+	return rand.New(rand.NewPCG(seed, seed)) //nolint:gosec
+}
+
+func takeIDs(candidates []model.ReviewerCandidate, count int) []string {
+	count = max(0, min(count, len(candidates)))
+
+	ids := make([]string, 0, count)
+	for _, c := range candidates[:count] {
+		ids = append(ids, c.UserID)
+	}
+
+	return ids
+}
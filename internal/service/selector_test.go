@@ -0,0 +1,173 @@
+package service
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"avito-test-assignment/internal/model"
+)
+
+// candidates lists the users in reverse id order, so selectors cannot rely on the input order.
+func candidates(load map[string]int) []model.ReviewerCandidate {
+	out := make([]model.ReviewerCandidate, 0, len(load))
+	for id, open := range load {
+		out = append(out, model.ReviewerCandidate{UserID: id, OpenReviews: open})
+	}
+
+	slices.SortFunc(out, func(a, b model.ReviewerCandidate) int {
+		if a.UserID < b.UserID {
+			return 1
+		}
+
+		return -1
+	})
+
+	return out
+}
+
+func TestLeastLoadedSelector(t *testing.T) {
+	tests := []struct {
+		name  string
+		load  map[string]int
+		count int
+		want  []string
+	}{
+		{"lowest load first", map[string]int{"u1": 3, "u2": 0, "u3": 1}, 2, []string{"u2", "u3"}},
+		{"ties by id", map[string]int{"u3": 1, "u1": 1, "u2": 1}, 2, []string{"u1", "u2"}},
+		{"count above candidates", map[string]int{"u1": 0}, 3, []string{"u1"}},
+		{"no candidates", nil, 2, []string{}},
+		{"zero count", map[string]int{"u1": 0}, 0, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := LeastLoadedSelector{}.Select("backend", candidates(tt.load), tt.count)
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundRobinSelector(t *testing.T) {
+	s := NewRoundRobinSelector()
+	pool := candidates(map[string]int{"u1": 0, "u2": 5, "u3": 1})
+
+	tests := []struct {
+		team  string
+		count int
+		want  []string
+	}{
+		{"backend", 2, []string{"u1", "u2"}},
+		{"backend", 2, []string{"u3", "u1"}},
+		{"frontend", 1, []string{"u1"}},
+		{"backend", 1, []string{"u2"}},
+		{"backend", 5, []string{"u3", "u1", "u2"}},
+		{"backend", 0, []string{}},
+	}
+
+	for i, tt := range tests {
+		if got := s.Select(tt.team, pool, tt.count); !slices.Equal(got, tt.want) {
+			t.Fatalf("call %d for %s: got %v, want %v", i, tt.team, got, tt.want)
+		}
+	}
+}
+
+func TestRoundRobinSelectorShrinkingTeam(t *testing.T) {
+	s := NewRoundRobinSelector()
+
+	s.Select("backend", candidates(map[string]int{"u1": 0, "u2": 0, "u3": 0}), 2)
+
+	got := s.Select("backend", candidates(map[string]int{"u1": 0, "u2": 0}), 1)
+	if !slices.Equal(got, []string{"u1"}) {
+		t.Fatalf("got %v, want the cursor to wrap to u1", got)
+	}
+}
+
+func TestRandomSelectorSeeded(t *testing.T) {
+	pool := candidates(map[string]int{"u1": 0, "u2": 0, "u3": 0, "u4": 0, "u5": 0})
+
+	a, b := NewRandomSelector(42), NewRandomSelector(42)
+
+	for i := range 10 {
+		got, want := a.Select("backend", pool, 2), b.Select("backend", pool, 2)
+		if !slices.Equal(got, want) {
+			t.Fatalf("call %d: same seed gave %v and %v", i, got, want)
+		}
+
+		if len(got) != 2 || got[0] == got[1] {
+			t.Fatalf("call %d: want two distinct reviewers, got %v", i, got)
+		}
+	}
+
+	if got := a.Select("backend", pool, 10); len(got) != len(pool) {
+		t.Fatalf("got %d reviewers, want all %d candidates", len(got), len(pool))
+	}
+}
+
+func TestWeightedSelector(t *testing.T) {
+	pool := candidates(map[string]int{"heavy": 0, "light": 0, "off": 0})
+	s := NewWeightedSelector(7, map[string]int{"heavy": 3, "off": 0})
+
+	const trials = 4000
+
+	picks := map[string]int{}
+
+	for range trials {
+		got := s.Select("backend", pool, 1)
+		if len(got) != 1 {
+			t.Fatalf("got %v, want one reviewer", got)
+		}
+
+		picks[got[0]]++
+	}
+
+	if picks["off"] != 0 {
+		t.Fatalf("zero weight reviewer picked %d times", picks["off"])
+	}
+
+	if share := float64(picks["heavy"]) / trials; share < 0.7 || share > 0.8 {
+		t.Fatalf("weight 3 against 1 picked %.2f of the time, want about 0.75", share)
+	}
+
+	got := s.Select("backend", pool, 3)
+	slices.Sort(got)
+
+	if !slices.Equal(got, []string{"heavy", "light"}) {
+		t.Fatalf("got %v, want every reviewer with a positive weight once", got)
+	}
+}
+
+func TestNewReviewerSelector(t *testing.T) {
+	if _, err := NewReviewerSelector(&SelectorConfig{Strategy: "fastest"}); !errors.Is(err, ErrUnknownSelectionStrategy) {
+		t.Fatalf("got %v, want ErrUnknownSelectionStrategy", err)
+	}
+
+	_, err := NewReviewerSelector(&SelectorConfig{Teams: map[string]string{"backend": "fastest"}})
+	if !errors.Is(err, ErrUnknownSelectionStrategy) {
+		t.Fatalf("got %v, want ErrUnknownSelectionStrategy for a team override", err)
+	}
+
+	s, err := NewReviewerSelector(&SelectorConfig{
+		Strategy: StrategyLeastLoaded,
+		Teams:    map[string]string{"backend": StrategyRoundRobin},
+	})
+	if err != nil {
+		t.Fatalf("NewReviewerSelector: %v", err)
+	}
+
+	pool := candidates(map[string]int{"u1": 0, "u2": 1})
+
+	for _, want := range []string{"u1", "u2", "u1"} {
+		if got := s.Select("backend", pool, 1); !slices.Equal(got, []string{want}) {
+			t.Fatalf("backend: got %v, want [%s]", got, want)
+		}
+	}
+
+	for range 3 {
+		if got := s.Select("frontend", pool, 1); !slices.Equal(got, []string{"u1"}) {
+			t.Fatalf("frontend: got %v, want the least loaded [u1]", got)
+		}
+	}
+}
@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"avito-test-assignment/internal/apperrors"
 	"avito-test-assignment/internal/model"
 	"avito-test-assignment/internal/repository"
 )
@@ -13,13 +15,49 @@ type PullRequestRepositoryForStats interface {
 	GetPRStats(ctx context.Context, ext repository.RepoExtension) ([]model.PRStats, error)
 }
 
+type EscalationRepositoryForStats interface {
+	GetEscalationStats(ctx context.Context, ext repository.RepoExtension) ([]model.EscalationStats, error)
+}
+
+type AnalyticsRepositoryForStats interface {
+	GetMergeTimeStats(
+		ctx context.Context,
+		ext repository.RepoExtension,
+		filter model.StatsFilter,
+		byAuthor bool,
+	) ([]model.MergeTimeStats, error)
+	GetOpenLoadStats(ctx context.Context, ext repository.RepoExtension, teamName string) ([]model.OpenLoadStats, error)
+	GetReassignmentStats(ctx context.Context, ext repository.RepoExtension, filter model.StatsFilter) ([]model.ReassignmentStats, error)
+	GetMergedPerWeekStats(ctx context.Context, ext repository.RepoExtension, filter model.StatsFilter) ([]model.MergedPerWeekStats, error)
+	GetMemberAssignments(
+		ctx context.Context,
+		ext repository.RepoExtension,
+		teamName string,
+		from, to time.Time,
+	) ([]model.MemberAssignments, error)
+	GetUnavailabilityInRange(
+		ctx context.Context,
+		ext repository.RepoExtension,
+		teamName string,
+		from, to time.Time,
+	) ([]model.Unavailability, error)
+}
+
 type StatsService struct {
 	pullRequestRepo PullRequestRepositoryForStats
+	escalationRepo  EscalationRepositoryForStats
+	analyticsRepo   AnalyticsRepositoryForStats
 }
 
-func NewStatsService(pullRequestRepo PullRequestRepositoryForStats) *StatsService {
+func NewStatsService(
+	pullRequestRepo PullRequestRepositoryForStats,
+	escalationRepo EscalationRepositoryForStats,
+	analyticsRepo AnalyticsRepositoryForStats,
+) *StatsService {
 	return &StatsService{
 		pullRequestRepo: pullRequestRepo,
+		escalationRepo:  escalationRepo,
+		analyticsRepo:   analyticsRepo,
 	}
 }
 
@@ -34,8 +72,94 @@ func (s *StatsService) GetStats(ctx context.Context) (response *model.StatsRespo
 		return nil, fmt.Errorf("failed to get PR stats: %w", err)
 	}
 
+	escalations, err := s.escalationRepo.GetEscalationStats(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get escalation stats: %w", err)
+	}
+
 	return &model.StatsResponse{
 		ReviewerStats: reviewer,
 		PRStats:       pr,
+		Escalations:   escalations,
+	}, nil
+}
+
+// GetAnalytics reports the current open load regardless of the filter.
+func (s *StatsService) GetAnalytics(ctx context.Context, filter model.StatsFilter) (*model.AnalyticsResponse, error) {
+	if !validRange(filter.From, filter.To) {
+		return nil, apperrors.ErrInvalidTimeRange
+	}
+
+	byTeam, err := s.analyticsRepo.GetMergeTimeStats(ctx, nil, filter, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merge time stats by team: %w", err)
+	}
+
+	byAuthor, err := s.analyticsRepo.GetMergeTimeStats(ctx, nil, filter, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merge time stats by author: %w", err)
+	}
+
+	openLoad, err := s.analyticsRepo.GetOpenLoadStats(ctx, nil, filter.TeamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get open load stats: %w", err)
+	}
+
+	reassignments, err := s.analyticsRepo.GetReassignmentStats(ctx, nil, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reassignment stats: %w", err)
+	}
+
+	perWeek, err := s.analyticsRepo.GetMergedPerWeekStats(ctx, nil, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merged per week stats: %w", err)
+	}
+
+	return &model.AnalyticsResponse{
+		TeamName:          filter.TeamName,
+		From:              filter.From,
+		To:                filter.To,
+		MergeTimeByTeam:   byTeam,
+		MergeTimeByAuthor: byAuthor,
+		OpenLoad:          openLoad,
+		Reassignments:     reassignments,
+		MergedPerWeek:     perWeek,
+	}, nil
+}
+
+// GetFairness defaults the window to the last 30 days.
+func (s *StatsService) GetFairness(ctx context.Context, filter model.StatsFilter) (*model.FairnessResponse, error) {
+	to := time.Now()
+	if filter.To != nil {
+		to = *filter.To
+	}
+
+	from := to.Add(-defaultFairnessWindow)
+	if filter.From != nil {
+		from = *filter.From
+	}
+
+	if !from.Before(to) {
+		return nil, apperrors.ErrInvalidTimeRange
+	}
+
+	members, err := s.analyticsRepo.GetMemberAssignments(ctx, nil, filter.TeamName, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get member assignments: %w", err)
+	}
+
+	unavailability, err := s.analyticsRepo.GetUnavailabilityInRange(ctx, nil, filter.TeamName, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unavailability: %w", err)
+	}
+
+	return &model.FairnessResponse{
+		From:  from,
+		To:    to,
+		Teams: teamFairness(members, unavailability, to),
 	}, nil
 }
+
+func validRange(from, to *time.Time) bool {
+	return from == nil || to == nil || from.Before(*to)
+}
@@ -6,36 +6,62 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"avito-test-assignment/internal/apperrors"
 	"avito-test-assignment/internal/model"
 	"avito-test-assignment/internal/repository"
+	"avito-test-assignment/internal/tracing"
 )
 
 type TeamRepositoryForTeam interface {
 	Pool() *pgxpool.Pool
 
 	InsertTeam(ctx context.Context, ext repository.RepoExtension, teamName string) (int, error)
-	SelectTeamIDByName(ctx context.Context, ext repository.RepoExtension, teamName string) (int, error)
+	SelectTeamByName(ctx context.Context, ext repository.RepoExtension, teamName string) (*model.Team, error)
+	UpdateTeamReviewersCount(ctx context.Context, ext repository.RepoExtension, teamName string, count int) error
+	UpdateTeamRequiredApprovals(ctx context.Context, ext repository.RepoExtension, teamName string, count int) error
+	UpdateTeamDefaultMaxOpenReviews(ctx context.Context, ext repository.RepoExtension, teamName string, limit *int) error
+	UpdateTeamReviewSLA(ctx context.Context, ext repository.RepoExtension, teamName string, minutes *int, action string) error
 	InsertTeamLinkWithUser(ctx context.Context, ext repository.RepoExtension, teamID int, userID string) error
+	DeleteTeamLinkWithUser(ctx context.Context, ext repository.RepoExtension, teamID int, userID string) error
 }
 
 type UserRepositoryForTeam interface {
 	UpsertUser(ctx context.Context, ext repository.RepoExtension, userID string, username string, isActive bool) error
+	InsertUserIfAbsent(ctx context.Context, ext repository.RepoExtension, userID string, username string, isActive bool) (bool, error)
+	SelectUserByID(ctx context.Context, ext repository.RepoExtension, userID string) (*model.User, error)
+	UpdateUserActive(ctx context.Context, ext repository.RepoExtension, userID string, isActive bool) error
 	SelectUsersByTeamID(ctx context.Context, ext repository.RepoExtension, teamID int) ([]model.User, error)
 }
 
+type ReviewReassigner interface {
+	ReassignOpenReviews(ctx context.Context, ext repository.RepoExtension, userID string, teamID *int, reason string) (*model.ReviewReassignments, error)
+}
+
 type TeamService struct {
-	teamRepo TeamRepositoryForTeam
-	userRepo UserRepositoryForTeam
+	teamRepo   TeamRepositoryForTeam
+	userRepo   UserRepositoryForTeam
+	audit      auditWriter
+	reassigner ReviewReassigner
 }
 
-func NewTeamService(teamRepo TeamRepositoryForTeam, userRepo UserRepositoryForTeam) *TeamService {
+func NewTeamService(
+	teamRepo TeamRepositoryForTeam,
+	userRepo UserRepositoryForTeam,
+	auditRepo AuditRepositoryForWrite,
+	reassigner ReviewReassigner,
+) *TeamService {
 	return &TeamService{
-		teamRepo: teamRepo,
-		userRepo: userRepo,
+		teamRepo:   teamRepo,
+		userRepo:   userRepo,
+		audit:      auditWriter{repo: auditRepo},
+		reassigner: reassigner,
 	}
 }
 
 func (s TeamService) AddTeam(ctx context.Context, teamName string, members []model.UserRequest) (err error) {
+	ctx, span := tracing.Start(ctx, "TeamService.AddTeam")
+	defer tracing.End(span, &err)
+
 	tx, err := s.teamRepo.Pool().Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
@@ -54,6 +80,8 @@ func (s TeamService) AddTeam(ctx context.Context, teamName string, members []mod
 		return fmt.Errorf("failed to insert team: %w", err)
 	}
 
+	memberIDs := make([]string, 0, len(members))
+
 	for _, user := range members {
 		if err = s.userRepo.UpsertUser(ctx, tx, user.UserID, user.Username, user.IsActive); err != nil {
 			return fmt.Errorf("failed to upsert user: %w", err)
@@ -62,6 +90,17 @@ func (s TeamService) AddTeam(ctx context.Context, teamName string, members []mod
 		if err = s.teamRepo.InsertTeamLinkWithUser(ctx, tx, teamID, user.UserID); err != nil {
 			return fmt.Errorf("failed to insert team link: %w", err)
 		}
+
+		memberIDs = append(memberIDs, user.UserID)
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action:   model.AuditTeamCreated,
+		teamName: teamName,
+		after:    map[string]any{"members": memberIDs},
+	})
+	if err != nil {
+		return err
 	}
 
 	if err = tx.Commit(ctx); err != nil {
@@ -72,6 +111,9 @@ func (s TeamService) AddTeam(ctx context.Context, teamName string, members []mod
 }
 
 func (s TeamService) GetTeam(ctx context.Context, teamName string) (team *model.TeamResponse, err error) {
+	ctx, span := tracing.Start(ctx, "TeamService.GetTeam")
+	defer tracing.End(span, &err)
+
 	tx, err := s.teamRepo.Pool().Begin(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to begin transaction: %w", err)
@@ -85,32 +127,554 @@ func (s TeamService) GetTeam(ctx context.Context, teamName string) (team *model.
 		}
 	}()
 
-	teamID, err := s.teamRepo.SelectTeamIDByName(ctx, tx, teamName)
+	team, err = s.selectTeam(ctx, tx, teamName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to select team ID: %w", err)
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	users, err := s.userRepo.SelectUsersByTeamID(ctx, tx, teamID)
+	return team, nil
+}
+
+func (s TeamService) SetReviewersCount(ctx context.Context, teamName string, count int) (team *model.TeamResponse, err error) {
+	ctx, span := tracing.Start(ctx, "TeamService.SetReviewersCount")
+	defer tracing.End(span, &err)
+
+	tx, err := s.teamRepo.Pool().Begin(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to select users: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	prev, err := s.teamRepo.SelectTeamByName(ctx, tx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select team: %w", err)
+	}
+
+	if err = validateApprovals(count, prev.RequiredApprovals); err != nil {
+		return nil, err
+	}
+
+	if err = s.teamRepo.UpdateTeamReviewersCount(ctx, tx, teamName, count); err != nil {
+		return nil, fmt.Errorf("failed to update reviewers count: %w", err)
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action:   model.AuditTeamSettingsChanged,
+		teamName: teamName,
+		before:   map[string]any{"reviewers_count": prev.ReviewersCount},
+		after:    map[string]any{"reviewers_count": count},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	team, err = s.selectTeam(ctx, tx, teamName)
+	if err != nil {
+		return nil, err
 	}
 
 	if err = tx.Commit(ctx); err != nil {
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
+	return team, nil
+}
+
+func (s TeamService) SetRequiredApprovals(ctx context.Context, teamName string, count int) (team *model.TeamResponse, err error) {
+	ctx, span := tracing.Start(ctx, "TeamService.SetRequiredApprovals")
+	defer tracing.End(span, &err)
+
+	tx, err := s.teamRepo.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	prev, err := s.teamRepo.SelectTeamByName(ctx, tx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select team: %w", err)
+	}
+
+	if err = validateApprovals(prev.ReviewersCount, count); err != nil {
+		return nil, err
+	}
+
+	if err = s.teamRepo.UpdateTeamRequiredApprovals(ctx, tx, teamName, count); err != nil {
+		return nil, fmt.Errorf("failed to update required approvals: %w", err)
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action:   model.AuditTeamSettingsChanged,
+		teamName: teamName,
+		before:   map[string]any{"required_approvals": prev.RequiredApprovals},
+		after:    map[string]any{"required_approvals": count},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	team, err = s.selectTeam(ctx, tx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return team, nil
+}
+
+func (s TeamService) SetMaxOpenReviews(ctx context.Context, teamName string, limit *int) (team *model.TeamResponse, err error) {
+	ctx, span := tracing.Start(ctx, "TeamService.SetMaxOpenReviews")
+	defer tracing.End(span, &err)
+
+	tx, err := s.teamRepo.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	prev, err := s.teamRepo.SelectTeamByName(ctx, tx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select team: %w", err)
+	}
+
+	if err = s.teamRepo.UpdateTeamDefaultMaxOpenReviews(ctx, tx, teamName, limit); err != nil {
+		return nil, fmt.Errorf("failed to update default max open reviews: %w", err)
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action:   model.AuditTeamSettingsChanged,
+		teamName: teamName,
+		before:   map[string]any{"default_max_open_reviews": prev.DefaultMaxOpenReviews},
+		after:    map[string]any{"default_max_open_reviews": limit},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	team, err = s.selectTeam(ctx, tx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return team, nil
+}
+
+// SetReviewSLA disables escalation when minutes is nil.
+func (s TeamService) SetReviewSLA(
+	ctx context.Context,
+	teamName string,
+	minutes *int,
+	action string,
+) (team *model.TeamResponse, err error) {
+	ctx, span := tracing.Start(ctx, "TeamService.SetReviewSLA")
+	defer tracing.End(span, &err)
+
+	tx, err := s.teamRepo.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	prev, err := s.teamRepo.SelectTeamByName(ctx, tx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select team: %w", err)
+	}
+
+	if action == "" {
+		action = prev.StaleReviewAction
+	}
+
+	if err = s.teamRepo.UpdateTeamReviewSLA(ctx, tx, teamName, minutes, action); err != nil {
+		return nil, fmt.Errorf("failed to update review SLA: %w", err)
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action:   model.AuditTeamSettingsChanged,
+		teamName: teamName,
+		before:   map[string]any{"review_sla_minutes": prev.ReviewSLAMinutes, "stale_review_action": prev.StaleReviewAction},
+		after:    map[string]any{"review_sla_minutes": minutes, "stale_review_action": action},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	team, err = s.selectTeam(ctx, tx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return team, nil
+}
+
+func (s TeamService) AddMembers(
+	ctx context.Context,
+	teamName string,
+	members []model.UserRequest,
+) (response *model.TeamMembersResponse, err error) {
+	ctx, span := tracing.Start(ctx, "TeamService.AddMembers")
+	defer tracing.End(span, &err)
+
+	tx, err := s.teamRepo.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	ctx, pending := withPendingMetrics(ctx)
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	t, err := s.teamRepo.SelectTeamByName(ctx, tx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select team: %w", err)
+	}
+
+	response = &model.TeamMembersResponse{
+		Reassigned: []model.ReviewReassignment{},
+		Uncovered:  []model.ReviewReassignment{},
+	}
+
+	for _, user := range members {
+		inserted, err := s.userRepo.InsertUserIfAbsent(ctx, tx, user.UserID, user.Username, user.IsActive)
+		if err != nil {
+			return nil, fmt.Errorf("failed to insert user: %w", err)
+		}
+
+		if err = s.teamRepo.InsertTeamLinkWithUser(ctx, tx, t.ID, user.UserID); err != nil {
+			return nil, fmt.Errorf("failed to insert team link: %w", err)
+		}
+
+		err = s.audit.record(ctx, tx, auditRecord{
+			action:   model.AuditTeamMemberAdded,
+			userID:   user.UserID,
+			teamName: teamName,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if inserted {
+			continue
+		}
+
+		reassigned, err := s.setMemberActive(ctx, tx, user.UserID, user.IsActive)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Reassigned = append(response.Reassigned, reassigned.Reassigned...)
+		response.Uncovered = append(response.Uncovered, reassigned.Uncovered...)
+	}
+
+	response.Team, err = s.selectTeam(ctx, tx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	pending.flush()
+
+	return response, nil
+}
+
+// setMemberActive mirrors UserService.SetIsActive, moving open reviews away on deactivation.
+func (s TeamService) setMemberActive(
+	ctx context.Context,
+	ext repository.RepoExtension,
+	userID string,
+	isActive bool,
+) (*model.ReviewReassignments, error) {
+	reassigned := &model.ReviewReassignments{}
+
+	prev, err := s.userRepo.SelectUserByID(ctx, ext, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select user: %w", err)
+	}
+
+	if prev.IsActive == isActive {
+		return reassigned, nil
+	}
+
+	if err = s.userRepo.UpdateUserActive(ctx, ext, userID, isActive); err != nil {
+		return nil, fmt.Errorf("failed to update user active: %w", err)
+	}
+
+	if !isActive {
+		reassigned, err = s.reassigner.ReassignOpenReviews(ctx, ext, userID, nil, reassignReasonDeactivated)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reassign open reviews: %w", err)
+		}
+	}
+
+	err = s.audit.record(ctx, ext, auditRecord{
+		action: model.AuditUserActivityChanged,
+		userID: userID,
+		before: map[string]any{"is_active": prev.IsActive},
+		after:  map[string]any{"is_active": isActive, "reassigned": reassigned.Reassigned, "uncovered": reassigned.Uncovered},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reassigned, nil
+}
+
+func (s TeamService) RemoveMembers(ctx context.Context, teamName string, userIDs []string) (response *model.TeamMembersResponse, err error) {
+	ctx, span := tracing.Start(ctx, "TeamService.RemoveMembers")
+	defer tracing.End(span, &err)
+
+	tx, err := s.teamRepo.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	ctx, pending := withPendingMetrics(ctx)
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	t, err := s.teamRepo.SelectTeamByName(ctx, tx, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select team: %w", err)
+	}
+
+	result := &model.ReviewReassignments{
+		Reassigned: []model.ReviewReassignment{},
+		Uncovered:  []model.ReviewReassignment{},
+	}
+
+	for _, userID := range userIDs {
+		moved, err := s.removeMember(ctx, tx, t.ID, userID, reassignReasonTeamRemoved)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.audit.record(ctx, tx, auditRecord{
+			action:   model.AuditTeamMemberRemoved,
+			userID:   userID,
+			teamName: teamName,
+			after:    map[string]any{"reassigned": moved.Reassigned, "uncovered": moved.Uncovered},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		result.Reassigned = append(result.Reassigned, moved.Reassigned...)
+		result.Uncovered = append(result.Uncovered, moved.Uncovered...)
+	}
+
+	team, err := s.selectTeam(ctx, tx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	pending.flush()
+
+	return &model.TeamMembersResponse{
+		Team:       team,
+		Reassigned: result.Reassigned,
+		Uncovered:  result.Uncovered,
+	}, nil
+}
+
+func (s TeamService) MoveMember(ctx context.Context, userID, fromTeam, toTeam string) (response *model.MoveTeamMemberResponse, err error) {
+	ctx, span := tracing.Start(ctx, "TeamService.MoveMember")
+	defer tracing.End(span, &err)
+
+	tx, err := s.teamRepo.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	ctx, pending := withPendingMetrics(ctx)
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	from, err := s.teamRepo.SelectTeamByName(ctx, tx, fromTeam)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select team: %w", err)
+	}
+
+	to, err := s.teamRepo.SelectTeamByName(ctx, tx, toTeam)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select team: %w", err)
+	}
+
+	if err = s.teamRepo.InsertTeamLinkWithUser(ctx, tx, to.ID, userID); err != nil {
+		return nil, fmt.Errorf("failed to insert team link: %w", err)
+	}
+
+	reassigned, err := s.removeMember(ctx, tx, from.ID, userID, reassignReasonTeamMoved)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action:   model.AuditTeamMemberMoved,
+		userID:   userID,
+		teamName: fromTeam,
+		before:   map[string]any{"team_name": fromTeam},
+		after:    map[string]any{"team_name": toTeam, "reassigned": reassigned.Reassigned, "uncovered": reassigned.Uncovered},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	fromResponse, err := s.selectTeam(ctx, tx, fromTeam)
+	if err != nil {
+		return nil, err
+	}
+
+	toResponse, err := s.selectTeam(ctx, tx, toTeam)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	pending.flush()
+
+	return &model.MoveTeamMemberResponse{
+		FromTeam:   fromResponse,
+		ToTeam:     toResponse,
+		Reassigned: reassigned.Reassigned,
+		Uncovered:  reassigned.Uncovered,
+	}, nil
+}
+
+func (s TeamService) removeMember(
+	ctx context.Context,
+	ext repository.RepoExtension,
+	teamID int,
+	userID, reason string,
+) (*model.ReviewReassignments, error) {
+	if err := s.teamRepo.DeleteTeamLinkWithUser(ctx, ext, teamID, userID); err != nil {
+		return nil, fmt.Errorf("failed to delete team link: %w", err)
+	}
+
+	reassigned, err := s.reassigner.ReassignOpenReviews(ctx, ext, userID, &teamID, reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reassign open reviews: %w", err)
+	}
+
+	return reassigned, nil
+}
+
+func (s TeamService) selectTeam(ctx context.Context, ext repository.RepoExtension, teamName string) (*model.TeamResponse, error) {
+	team, err := s.teamRepo.SelectTeamByName(ctx, ext, teamName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select team: %w", err)
+	}
+
+	users, err := s.userRepo.SelectUsersByTeamID(ctx, ext, team.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select users: %w", err)
+	}
+
 	usersResponse := make([]model.UserResponse, 0, len(users))
 
 	for _, user := range users {
 		usersResponse = append(usersResponse, model.UserResponse{
-			UserID:   user.ID,
-			Username: user.Username,
-			IsActive: user.IsActive,
+			UserID:         user.ID,
+			Username:       user.Username,
+			IsActive:       user.IsActive,
+			MaxOpenReviews: user.MaxOpenReviews,
 		})
 	}
 
 	return &model.TeamResponse{
-		TeamName: teamName,
-		Members:  usersResponse,
+		TeamID:                team.ID,
+		TeamName:              team.Name,
+		ReviewersCount:        team.ReviewersCount,
+		RequiredApprovals:     team.RequiredApprovals,
+		DefaultMaxOpenReviews: team.DefaultMaxOpenReviews,
+		ReviewSLAMinutes:      team.ReviewSLAMinutes,
+		StaleReviewAction:     staleReviewAction(team),
+		Members:               usersResponse,
 	}, nil
 }
+
+func validateApprovals(reviewersCount, requiredApprovals int) error {
+	if requiredApprovals > reviewersCount {
+		return apperrors.ErrApprovalsExceedReviewers
+	}
+
+	return nil
+}
+
+// staleReviewAction hides the escalation action of teams without a review SLA.
+func staleReviewAction(team *model.Team) string {
+	if team.ReviewSLAMinutes == nil {
+		return ""
+	}
+
+	return team.StaleReviewAction
+}
@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"avito-test-assignment/internal/apperrors"
+	"avito-test-assignment/internal/model"
+	"avito-test-assignment/internal/repository"
+)
+
+func TestValidateApprovals(t *testing.T) {
+	tests := []struct {
+		reviewers, approvals int
+		want                 error
+	}{
+		{2, 0, nil},
+		{2, 2, nil},
+		{2, 3, apperrors.ErrApprovalsExceedReviewers},
+		{1, 2, apperrors.ErrApprovalsExceedReviewers},
+	}
+
+	for _, tt := range tests {
+		if err := validateApprovals(tt.reviewers, tt.approvals); !errors.Is(err, tt.want) {
+			t.Fatalf("validateApprovals(%d, %d) = %v, want %v", tt.reviewers, tt.approvals, err, tt.want)
+		}
+	}
+}
+
+type fakeTeamUserRepo struct {
+	UserRepositoryForTeam
+
+	active map[string]bool
+}
+
+func (r *fakeTeamUserRepo) SelectUserByID(_ context.Context, _ repository.RepoExtension, userID string) (*model.User, error) {
+	isActive, ok := r.active[userID]
+	if !ok {
+		return nil, apperrors.ErrUserNotExist
+	}
+
+	return &model.User{ID: userID, IsActive: isActive}, nil
+}
+
+func (r *fakeTeamUserRepo) UpdateUserActive(_ context.Context, _ repository.RepoExtension, userID string, isActive bool) error {
+	r.active[userID] = isActive
+
+	return nil
+}
+
+type fakeReviewReassigner struct {
+	reassigned []string
+}
+
+func (f *fakeReviewReassigner) ReassignOpenReviews(
+	_ context.Context,
+	_ repository.RepoExtension,
+	userID string,
+	_ *int,
+	reason string,
+) (*model.ReviewReassignments, error) {
+	f.reassigned = append(f.reassigned, userID+":"+reason)
+
+	return &model.ReviewReassignments{
+		Uncovered: []model.ReviewReassignment{{PullRequestID: "pr-1", OldReviewerID: userID}},
+	}, nil
+}
+
+type fakeAuditRepo struct {
+	events []*model.AuditEvent
+}
+
+func (f *fakeAuditRepo) InsertAuditEvent(_ context.Context, _ repository.RepoExtension, event *model.AuditEvent) error {
+	f.events = append(f.events, event)
+
+	return nil
+}
+
+func TestSetMemberActive(t *testing.T) {
+	users := &fakeTeamUserRepo{active: map[string]bool{"u1": true, "u2": true}}
+	reassigner := &fakeReviewReassigner{}
+	audit := &fakeAuditRepo{}
+	s := NewTeamService(nil, users, audit, reassigner)
+
+	got, err := s.setMemberActive(context.Background(), nil, "u1", true)
+	if err != nil || len(got.Reassigned)+len(got.Uncovered) != 0 {
+		t.Fatalf("unchanged member: %+v, %v", got, err)
+	}
+
+	got, err = s.setMemberActive(context.Background(), nil, "u2", false)
+	if err != nil {
+		t.Fatalf("setMemberActive: %v", err)
+	}
+
+	if users.active["u2"] || len(got.Uncovered) != 1 {
+		t.Fatalf("deactivated member: active=%v, %+v", users.active["u2"], got)
+	}
+
+	if !slices.Equal(reassigner.reassigned, []string{"u2:" + reassignReasonDeactivated}) {
+		t.Fatalf("reassigned %v", reassigner.reassigned)
+	}
+
+	if len(audit.events) != 1 || audit.events[0].Action != model.AuditUserActivityChanged || audit.events[0].UserID != "u2" {
+		t.Fatalf("audit events %+v", audit.events)
+	}
+}
@@ -0,0 +1,298 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/model"
+	"avito-test-assignment/internal/repository"
+)
+
+const (
+	defaultUnavailabilityCheckInterval = time.Minute
+	defaultUnavailabilityBatchSize     = 100
+)
+
+type UnavailabilityRepositoryForUnavailability interface {
+	Pool() *pgxpool.Pool
+
+	InsertUnavailability(
+		ctx context.Context,
+		ext repository.RepoExtension,
+		userID string,
+		startsAt, endsAt time.Time,
+		reason string,
+	) (*model.Unavailability, error)
+	SelectUnavailability(ctx context.Context, ext repository.RepoExtension, userID string, includePast bool) ([]model.Unavailability, error)
+	DeleteUnavailability(ctx context.Context, ext repository.RepoExtension, id int64) (*model.Unavailability, error)
+	ClaimStartedUnavailability(ctx context.Context, ext repository.RepoExtension, limit int) ([]model.Unavailability, error)
+	MarkReassigned(ctx context.Context, ext repository.RepoExtension, id int64) error
+}
+
+type UserRepositoryForUnavailability interface {
+	SelectUserByID(ctx context.Context, ext repository.RepoExtension, userID string) (*model.User, error)
+}
+
+type UnavailabilityService struct {
+	unavailabilityRepo UnavailabilityRepositoryForUnavailability
+	userRepo           UserRepositoryForUnavailability
+	audit              auditWriter
+	reassigner         ReviewReassigner
+}
+
+func NewUnavailabilityService(
+	unavailabilityRepo UnavailabilityRepositoryForUnavailability,
+	userRepo UserRepositoryForUnavailability,
+	auditRepo AuditRepositoryForWrite,
+	reassigner ReviewReassigner,
+) *UnavailabilityService {
+	return &UnavailabilityService{
+		unavailabilityRepo: unavailabilityRepo,
+		userRepo:           userRepo,
+		audit:              auditWriter{repo: auditRepo},
+		reassigner:         reassigner,
+	}
+}
+
+func (s *UnavailabilityService) Create(
+	ctx context.Context,
+	req *model.CreateUnavailabilityRequest,
+) (response *model.CreateUnavailabilityResponse, err error) {
+	tx, err := s.unavailabilityRepo.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	ctx, pending := withPendingMetrics(ctx)
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	if _, err = s.userRepo.SelectUserByID(ctx, tx, req.UserID); err != nil {
+		return nil, fmt.Errorf("failed to select user: %w", err)
+	}
+
+	period, err := s.unavailabilityRepo.InsertUnavailability(ctx, tx, req.UserID, req.StartsAt, req.EndsAt, req.Reason)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert unavailability: %w", err)
+	}
+
+	response = &model.CreateUnavailabilityResponse{}
+
+	// An absence already in progress is handled right away.
+	now := time.Now()
+	if !period.StartsAt.After(now) && period.EndsAt.After(now) {
+		reassigned, err := s.reassignPeriod(ctx, tx, period)
+		if err != nil {
+			return nil, err
+		}
+
+		response.Reassigned = reassigned.Reassigned
+		response.Uncovered = reassigned.Uncovered
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action: model.AuditUnavailabilityAdded,
+		userID: period.UserID,
+		after:  period,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	pending.flush()
+
+	response.Unavailability = *period
+
+	return response, nil
+}
+
+func (s *UnavailabilityService) List(ctx context.Context, qp *model.UnavailabilityQueryParam) (*model.UnavailabilityListResponse, error) {
+	periods, err := s.unavailabilityRepo.SelectUnavailability(ctx, nil, qp.UserID, qp.IncludePast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select unavailability: %w", err)
+	}
+
+	return &model.UnavailabilityListResponse{
+		Unavailability: periods,
+	}, nil
+}
+
+func (s *UnavailabilityService) Delete(ctx context.Context, id int64) (err error) {
+	tx, err := s.unavailabilityRepo.Pool().Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	period, err := s.unavailabilityRepo.DeleteUnavailability(ctx, tx, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete unavailability: %w", err)
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action: model.AuditUnavailabilityRemoved,
+		userID: period.UserID,
+		before: period,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ReassignStarted handles every absence in its own savepoint so a failed one is retried next run.
+func (s *UnavailabilityService) ReassignStarted(ctx context.Context, limit int) (int, error) {
+	processed, failures, err := s.reassignStarted(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	return processed, errors.Join(failures...)
+}
+
+func (s *UnavailabilityService) reassignStarted(ctx context.Context, limit int) (processed int, failures []error, err error) {
+	tx, err := s.unavailabilityRepo.Pool().Begin(ctx)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	ctx, pending := withPendingMetrics(ctx)
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	periods, err := s.unavailabilityRepo.ClaimStartedUnavailability(ctx, tx, limit)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to claim started unavailability: %w", err)
+	}
+
+	for i := range periods {
+		failed, err := inSavepoint(ctx, tx, func(ctx context.Context, sp pgx.Tx) error {
+			_, err := s.reassignPeriod(ctx, sp, &periods[i])
+
+			return err
+		})
+		if err != nil {
+			return 0, nil, err
+		}
+
+		if failed != nil {
+			failures = append(failures, fmt.Errorf("unavailability %d of %s: %w", periods[i].ID, periods[i].UserID, failed))
+
+			continue
+		}
+
+		processed++
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	pending.flush()
+
+	return processed, failures, nil
+}
+
+func (s *UnavailabilityService) reassignPeriod(
+	ctx context.Context,
+	ext repository.RepoExtension,
+	period *model.Unavailability,
+) (*model.ReviewReassignments, error) {
+	reassigned, err := s.reassigner.ReassignOpenReviews(ctx, ext, period.UserID, nil, reassignReasonUnavailable)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reassign open reviews: %w", err)
+	}
+
+	if err = s.unavailabilityRepo.MarkReassigned(ctx, ext, period.ID); err != nil {
+		return nil, fmt.Errorf("failed to mark unavailability as reassigned: %w", err)
+	}
+
+	now := time.Now()
+	period.ReassignedAt = &now
+
+	return reassigned, nil
+}
+
+type UnavailabilityReassigner interface {
+	ReassignStarted(ctx context.Context, limit int) (int, error)
+}
+
+type UnavailabilityWatcher struct {
+	l         *zap.Logger
+	svc       UnavailabilityReassigner
+	interval  time.Duration
+	batchSize int
+}
+
+func NewUnavailabilityWatcher(l *zap.Logger, svc UnavailabilityReassigner, interval time.Duration, batchSize int) *UnavailabilityWatcher {
+	if interval <= 0 {
+		interval = defaultUnavailabilityCheckInterval
+	}
+
+	if batchSize <= 0 {
+		batchSize = defaultUnavailabilityBatchSize
+	}
+
+	return &UnavailabilityWatcher{
+		l:         l,
+		svc:       svc,
+		interval:  interval,
+		batchSize: batchSize,
+	}
+}
+
+func (w *UnavailabilityWatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		processed, err := w.svc.ReassignStarted(ctx, w.batchSize)
+		if err != nil && ctx.Err() == nil {
+			w.l.Error("Failed to reassign reviews of unavailable users", zap.Error(err))
+		}
+
+		if processed > 0 {
+			w.l.Info("Reviews of unavailable users reassigned", zap.Int("absences", processed))
+		}
+	}
+}
@@ -3,22 +3,30 @@ package service
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"avito-test-assignment/internal/apperrors"
 	"avito-test-assignment/internal/model"
 	"avito-test-assignment/internal/repository"
+	"avito-test-assignment/internal/tracing"
 )
 
 type TeamRepositoryForUser interface {
 	Pool() *pgxpool.Pool
 
-	SelectTeamNameByUserID(ctx context.Context, ext repository.RepoExtension, userID string) (string, error)
+	SelectTeamsByUserID(ctx context.Context, ext repository.RepoExtension, userID string) ([]model.Team, error)
+	SelectTeamByName(ctx context.Context, ext repository.RepoExtension, teamName string) (*model.Team, error)
 }
 
 type UserRepositoryForUser interface {
 	UpdateUserActive(ctx context.Context, ext repository.RepoExtension, userID string, isActive bool) error
 	SelectUserByID(ctx context.Context, ext repository.RepoExtension, userID string) (*model.User, error)
+	SelectUsersByTeamID(ctx context.Context, ext repository.RepoExtension, teamID int) ([]model.User, error)
+	UpdateUsersActive(ctx context.Context, ext repository.RepoExtension, userIDs []string, isActive bool) ([]string, error)
+	UpdateUserMaxOpenReviews(ctx context.Context, ext repository.RepoExtension, userID string, limit *int) error
+	SelectReviewLoad(ctx context.Context, ext repository.RepoExtension, userID string) (*model.ReviewLoad, error)
 }
 
 type PullRequestRepositoryForUser interface {
@@ -31,22 +39,41 @@ type UserService struct {
 	teamRepo        TeamRepositoryForUser
 	userRepo        UserRepositoryForUser
 	pullRequestRepo PullRequestRepositoryForUser
+	audit           auditWriter
+	reassigner      ReviewReassigner
 }
 
-func NewUserService(teamRepo TeamRepositoryForUser, userRepo UserRepositoryForUser, pullRequestRepo PullRequestRepositoryForUser) *UserService {
+func NewUserService(
+	teamRepo TeamRepositoryForUser,
+	userRepo UserRepositoryForUser,
+	pullRequestRepo PullRequestRepositoryForUser,
+	auditRepo AuditRepositoryForWrite,
+	reassigner ReviewReassigner,
+) *UserService {
 	return &UserService{
 		teamRepo:        teamRepo,
 		userRepo:        userRepo,
 		pullRequestRepo: pullRequestRepo,
+		audit:           auditWriter{repo: auditRepo},
+		reassigner:      reassigner,
 	}
 }
 
-func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive bool) (user *model.UserResponseWithTeamName, err error) {
+func (s *UserService) SetIsActive(
+	ctx context.Context,
+	userID string,
+	isActive bool,
+) (user *model.UserResponseWithTeamName, reassigned *model.ReviewReassignments, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.SetIsActive")
+	defer tracing.End(span, &err)
+
 	tx, err := s.teamRepo.Pool().Begin(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
 
+	ctx, pending := withPendingMetrics(ctx)
+
 	defer func() {
 		if err != nil {
 			if rErr := tx.Rollback(ctx); rErr != nil {
@@ -55,34 +82,238 @@ func (s *UserService) SetIsActive(ctx context.Context, userID string, isActive b
 		}
 	}()
 
-	if err := s.userRepo.UpdateUserActive(ctx, tx, userID, isActive); err != nil {
-		return nil, fmt.Errorf("failed to update user active: %w", err)
+	prev, err := s.userRepo.SelectUserByID(ctx, tx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to select user: %w", err)
+	}
+
+	if err = s.userRepo.UpdateUserActive(ctx, tx, userID, isActive); err != nil {
+		return nil, nil, fmt.Errorf("failed to update user active: %w", err)
 	}
 
-	teamName, err := s.teamRepo.SelectTeamNameByUserID(ctx, tx, userID)
+	teams, err := s.teamRepo.SelectTeamsByUserID(ctx, tx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to select team name: %w", err)
+		return nil, nil, fmt.Errorf("failed to select teams: %w", err)
+	}
+
+	if len(teams) == 0 {
+		return nil, nil, fmt.Errorf("failed to select teams: %w", apperrors.ErrTeamNotExist)
 	}
 
 	userFull, err := s.userRepo.SelectUserByID(ctx, tx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to select user: %w", err)
+		return nil, nil, fmt.Errorf("failed to select user: %w", err)
+	}
+
+	reassigned = &model.ReviewReassignments{}
+
+	if !isActive {
+		reassigned, err = s.reassigner.ReassignOpenReviews(ctx, tx, userID, nil, reassignReasonDeactivated)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to reassign open reviews: %w", err)
+		}
+	}
+
+	if prev.IsActive != isActive {
+		err = s.audit.record(ctx, tx, auditRecord{
+			action: model.AuditUserActivityChanged,
+			userID: userID,
+			before: map[string]any{"is_active": prev.IsActive},
+			after:  map[string]any{"is_active": isActive, "reassigned": reassigned.Reassigned, "uncovered": reassigned.Uncovered},
+		})
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
 	if err = tx.Commit(ctx); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	pending.flush()
+
+	userTeams := make([]model.UserTeam, 0, len(teams))
+
+	for _, team := range teams {
+		userTeams = append(userTeams, model.UserTeam{
+			TeamID:   team.ID,
+			TeamName: team.Name,
+		})
+	}
+
+	var teamName string
+	if len(teams) > 0 {
+		teamName = teams[0].Name
 	}
 
 	return &model.UserResponseWithTeamName{
 		TeamName: teamName,
+		Teams:    userTeams,
 		UserID:   userFull.ID,
 		Username: userFull.Username,
 		IsActive: userFull.IsActive,
+	}, reassigned, nil
+}
+
+func (s *UserService) BulkDeactivate(
+	ctx context.Context,
+	teamName string,
+	userIDs []string,
+) (response *model.BulkDeactivateResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.BulkDeactivate")
+	defer tracing.End(span, &err)
+
+	tx, err := s.teamRepo.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	ctx, pending := withPendingMetrics(ctx)
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	ids := slices.Clone(userIDs)
+
+	if teamName != "" {
+		team, err := s.teamRepo.SelectTeamByName(ctx, tx, teamName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select team: %w", err)
+		}
+
+		members, err := s.userRepo.SelectUsersByTeamID(ctx, tx, team.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select team members: %w", err)
+		}
+
+		for _, member := range members {
+			ids = append(ids, member.ID)
+		}
+	}
+
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	wasActive := make(map[string]bool, len(ids))
+
+	for _, userID := range ids {
+		user, err := s.userRepo.SelectUserByID(ctx, tx, userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select user: %w", err)
+		}
+
+		wasActive[userID] = user.IsActive
+	}
+
+	// Everyone is deactivated before reassignment so that users from the same batch
+	// are never picked as replacements for each other.
+	if _, err = s.userRepo.UpdateUsersActive(ctx, tx, ids, false); err != nil {
+		return nil, fmt.Errorf("failed to deactivate users: %w", err)
+	}
+
+	response = &model.BulkDeactivateResponse{
+		Deactivated: ids,
+		Reassigned:  []model.ReviewReassignment{},
+		Uncovered:   []model.ReviewReassignment{},
+	}
+
+	for _, userID := range ids {
+		reassigned, err := s.reassigner.ReassignOpenReviews(ctx, tx, userID, nil, reassignReasonDeactivated)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reassign open reviews: %w", err)
+		}
+
+		if wasActive[userID] {
+			err = s.audit.record(ctx, tx, auditRecord{
+				action:   model.AuditUserActivityChanged,
+				userID:   userID,
+				teamName: teamName,
+				before:   map[string]any{"is_active": true},
+				after:    map[string]any{"is_active": false, "reassigned": reassigned.Reassigned, "uncovered": reassigned.Uncovered},
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		response.Reassigned = append(response.Reassigned, reassigned.Reassigned...)
+		response.Uncovered = append(response.Uncovered, reassigned.Uncovered...)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	pending.flush()
+
+	return response, nil
+}
+
+func (s *UserService) SetMaxOpenReviews(
+	ctx context.Context,
+	userID string,
+	limit *int,
+) (response *model.ReviewLoadResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.SetMaxOpenReviews")
+	defer tracing.End(span, &err)
+
+	tx, err := s.teamRepo.Pool().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			if rErr := tx.Rollback(ctx); rErr != nil {
+				err = fmt.Errorf("%w, failed to rollback: %w", err, rErr)
+			}
+		}
+	}()
+
+	prev, err := s.userRepo.SelectUserByID(ctx, tx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select user: %w", err)
+	}
+
+	if err = s.userRepo.UpdateUserMaxOpenReviews(ctx, tx, userID, limit); err != nil {
+		return nil, fmt.Errorf("failed to update max open reviews: %w", err)
+	}
+
+	err = s.audit.record(ctx, tx, auditRecord{
+		action: model.AuditUserSettingsChanged,
+		userID: userID,
+		before: map[string]any{"max_open_reviews": prev.MaxOpenReviews},
+		after:  map[string]any{"max_open_reviews": limit},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	load, err := s.reviewLoad(ctx, tx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &model.ReviewLoadResponse{
+		ReviewLoad: *load,
+		UserID:     userID,
 	}, nil
 }
 
-func (s *UserService) GetReview(ctx context.Context, userID string) (*model.GetReviewResponse, error) {
-	_, err := s.userRepo.SelectUserByID(ctx, nil, userID)
+func (s *UserService) GetReview(ctx context.Context, userID string) (response *model.GetReviewResponse, err error) {
+	ctx, span := tracing.Start(ctx, "UserService.GetReview")
+	defer tracing.End(span, &err)
+
+	_, err = s.userRepo.SelectUserByID(ctx, nil, userID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to select user: %w", err)
 	}
@@ -103,8 +334,25 @@ func (s *UserService) GetReview(ctx context.Context, userID string) (*model.GetR
 		})
 	}
 
+	load, err := s.reviewLoad(ctx, nil, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	return &model.GetReviewResponse{
 		UserID:       userID,
 		PullRequests: prsResponse,
+		Load:         *load,
 	}, nil
 }
+
+func (s *UserService) reviewLoad(ctx context.Context, ext repository.RepoExtension, userID string) (*model.ReviewLoad, error) {
+	load, err := s.userRepo.SelectReviewLoad(ctx, ext, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select review load: %w", err)
+	}
+
+	load.AtCapacity = load.MaxOpenReviews != nil && load.OpenReviews >= *load.MaxOpenReviews
+
+	return load, nil
+}
@@ -0,0 +1,335 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/model"
+	"avito-test-assignment/internal/repository"
+)
+
+const (
+	WebhookSignatureHeader = "X-Webhook-Signature"
+	WebhookTimestampHeader = "X-Webhook-Timestamp"
+	WebhookEventHeader     = "X-Webhook-Event"
+	WebhookDeliveryHeader  = "X-Webhook-Delivery"
+
+	defaultWebhookPollInterval   = time.Second
+	defaultWebhookRequestTimeout = 5 * time.Second
+	defaultWebhookBatchSize      = 50
+	defaultWebhookMaxAttempts    = 8
+	defaultWebhookBackoffBase    = 2 * time.Second
+	defaultWebhookBackoffMax     = 10 * time.Minute
+
+	webhookSecretBytes   = 32
+	webhookErrorBodySize = 512
+)
+
+type OutboxRepositoryForWrite interface {
+	InsertOutboxEvent(ctx context.Context, ext repository.RepoExtension, eventType string, payload []byte) error
+}
+
+type outboxWriter struct {
+	repo OutboxRepositoryForWrite
+}
+
+func (w outboxWriter) publish(ctx context.Context, ext repository.RepoExtension, eventType string, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s event: %w", eventType, err)
+	}
+
+	if err = w.repo.InsertOutboxEvent(ctx, ext, eventType, payload); err != nil {
+		return fmt.Errorf("failed to write outbox event %s: %w", eventType, err)
+	}
+
+	return nil
+}
+
+func SignWebhookPayload(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type WebhookRepositoryForWebhook interface {
+	InsertSubscription(ctx context.Context, ext repository.RepoExtension, url, secret string, events []string) (*model.WebhookSubscription, error)
+	SelectSubscriptions(ctx context.Context, ext repository.RepoExtension) ([]model.WebhookSubscription, error)
+	DeleteSubscription(ctx context.Context, ext repository.RepoExtension, id int64) error
+	SelectDeadDeliveries(ctx context.Context, ext repository.RepoExtension, subscriptionID int64, limit int) ([]model.WebhookDelivery, error)
+	RequeueDeadDelivery(ctx context.Context, ext repository.RepoExtension, id int64) error
+}
+
+type WebhookService struct {
+	webhookRepo WebhookRepositoryForWebhook
+}
+
+func NewWebhookService(webhookRepo WebhookRepositoryForWebhook) *WebhookService {
+	return &WebhookService{
+		webhookRepo: webhookRepo,
+	}
+}
+
+func (s *WebhookService) Create(ctx context.Context, url, secret string, events []string) (*model.WebhookSubscription, error) {
+	if secret == "" {
+		raw := make([]byte, webhookSecretBytes)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+		}
+
+		secret = hex.EncodeToString(raw)
+	}
+
+	if events == nil {
+		events = []string{}
+	}
+
+	sub, err := s.webhookRepo.InsertSubscription(ctx, nil, url, secret, events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert webhook subscription: %w", err)
+	}
+
+	return sub, nil
+}
+
+func (s *WebhookService) List(ctx context.Context) (*model.WebhookListResponse, error) {
+	subs, err := s.webhookRepo.SelectSubscriptions(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select webhook subscriptions: %w", err)
+	}
+
+	return &model.WebhookListResponse{
+		Webhooks: subs,
+	}, nil
+}
+
+func (s *WebhookService) Delete(ctx context.Context, id int64) error {
+	if err := s.webhookRepo.DeleteSubscription(ctx, nil, id); err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (s *WebhookService) DeadLetters(ctx context.Context, qp *model.DeadLettersQueryParam) (*model.DeadLettersResponse, error) {
+	limit := qp.Limit
+	if limit == 0 {
+		limit = defaultPageLimit
+	}
+
+	deliveries, err := s.webhookRepo.SelectDeadDeliveries(ctx, nil, qp.SubscriptionID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select dead deliveries: %w", err)
+	}
+
+	return &model.DeadLettersResponse{
+		Deliveries: deliveries,
+	}, nil
+}
+
+func (s *WebhookService) Redeliver(ctx context.Context, deliveryID int64) error {
+	if err := s.webhookRepo.RequeueDeadDelivery(ctx, nil, deliveryID); err != nil {
+		return fmt.Errorf("failed to requeue delivery: %w", err)
+	}
+
+	return nil
+}
+
+type WebhookRepositoryForDispatcher interface {
+	FanOutOutboxEvents(ctx context.Context, ext repository.RepoExtension, limit int) (int64, error)
+	ClaimDueDeliveries(ctx context.Context, ext repository.RepoExtension, limit int, lease time.Duration) ([]model.WebhookDelivery, error)
+	UpdateDeliveryAttempt(ctx context.Context, ext repository.RepoExtension, result *model.WebhookDeliveryResult) error
+}
+
+type DispatcherConfig struct {
+	PollInterval   time.Duration
+	RequestTimeout time.Duration
+	BatchSize      int
+	MaxAttempts    int
+	BackoffBase    time.Duration
+	BackoffMax     time.Duration
+}
+
+type WebhookDispatcher struct {
+	l      *zap.Logger
+	repo   WebhookRepositoryForDispatcher
+	client *http.Client
+	cfg    DispatcherConfig
+	now    func() time.Time
+}
+
+func NewWebhookDispatcher(l *zap.Logger, repo WebhookRepositoryForDispatcher, cfg DispatcherConfig) *WebhookDispatcher {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultWebhookPollInterval
+	}
+
+	if cfg.RequestTimeout <= 0 {
+		cfg.RequestTimeout = defaultWebhookRequestTimeout
+	}
+
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultWebhookBatchSize
+	}
+
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = defaultWebhookMaxAttempts
+	}
+
+	if cfg.BackoffBase <= 0 {
+		cfg.BackoffBase = defaultWebhookBackoffBase
+	}
+
+	if cfg.BackoffMax <= 0 {
+		cfg.BackoffMax = defaultWebhookBackoffMax
+	}
+
+	return &WebhookDispatcher{
+		l:      l,
+		repo:   repo,
+		client: &http.Client{Timeout: cfg.RequestTimeout},
+		cfg:    cfg,
+		now:    time.Now,
+	}
+}
+
+func (d *WebhookDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		if err := d.Tick(ctx); err != nil && ctx.Err() == nil {
+			d.l.Error("Webhook dispatch failed", zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (d *WebhookDispatcher) Tick(ctx context.Context) error {
+	if _, err := d.repo.FanOutOutboxEvents(ctx, nil, d.cfg.BatchSize); err != nil {
+		return fmt.Errorf("failed to fan out outbox events: %w", err)
+	}
+
+	// Claimed rows are delivered one after another, so the lease has to outlast the whole batch
+	// or another dispatcher would claim the tail of it again.
+	lease := d.cfg.RequestTimeout * time.Duration(d.cfg.BatchSize+1)
+
+	deliveries, err := d.repo.ClaimDueDeliveries(ctx, nil, d.cfg.BatchSize, lease)
+	if err != nil {
+		return fmt.Errorf("failed to claim deliveries: %w", err)
+	}
+
+	for i := range deliveries {
+		result := d.deliver(ctx, &deliveries[i])
+
+		if err = d.repo.UpdateDeliveryAttempt(ctx, nil, result); err != nil {
+			return fmt.Errorf("failed to update delivery %d: %w", result.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (d *WebhookDispatcher) deliver(ctx context.Context, delivery *model.WebhookDelivery) *model.WebhookDeliveryResult {
+	result := &model.WebhookDeliveryResult{
+		ID:       delivery.ID,
+		Attempts: delivery.Attempts + 1,
+	}
+
+	statusCode, err := d.send(ctx, delivery)
+	if statusCode != 0 {
+		result.StatusCode = &statusCode
+	}
+
+	now := d.now()
+
+	switch {
+	case err == nil:
+		result.Status = model.WebhookDeliveryDelivered
+		result.NextAttemptAt = now
+	case result.Attempts >= d.cfg.MaxAttempts:
+		result.Status = model.WebhookDeliveryDead
+		result.Error = err.Error()
+		result.NextAttemptAt = now
+	default:
+		result.Status = model.WebhookDeliveryPending
+		result.Error = err.Error()
+		result.NextAttemptAt = now.Add(d.backoff(result.Attempts))
+	}
+
+	return result
+}
+
+func (d *WebhookDispatcher) send(ctx context.Context, delivery *model.WebhookDelivery) (int, error) {
+	body, err := json.Marshal(model.WebhookEnvelope{
+		EventID:    delivery.EventID,
+		Event:      delivery.EventType,
+		OccurredAt: delivery.EventCreatedAt,
+		Data:       delivery.Payload,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal webhook body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	timestamp := d.now().Unix()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(WebhookEventHeader, delivery.EventType)
+	req.Header.Set(WebhookDeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(WebhookTimestampHeader, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(WebhookSignatureHeader, SignWebhookPayload(delivery.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, webhookErrorBodySize))
+
+		return resp.StatusCode, fmt.Errorf("receiver responded with %d: %s", resp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	return resp.StatusCode, nil
+}
+
+func (d *WebhookDispatcher) backoff(attempt int) time.Duration {
+	delay := d.cfg.BackoffBase
+
+	for i := 1; i < attempt; i++ {
+		delay *= 2
+		if delay >= d.cfg.BackoffMax {
+			return d.cfg.BackoffMax
+		}
+	}
+
+	return delay
+}
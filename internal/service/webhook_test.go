@@ -0,0 +1,249 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+
+	"avito-test-assignment/internal/model"
+	"avito-test-assignment/internal/repository"
+)
+
+type fakeDispatcherRepo struct {
+	mu         sync.Mutex
+	pending    []model.WebhookDelivery
+	results    []model.WebhookDeliveryResult
+	fanOutRuns int
+	lease      time.Duration
+}
+
+func (r *fakeDispatcherRepo) FanOutOutboxEvents(_ context.Context, _ repository.RepoExtension, _ int) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fanOutRuns++
+
+	return 0, nil
+}
+
+func (r *fakeDispatcherRepo) ClaimDueDeliveries(
+	_ context.Context,
+	_ repository.RepoExtension,
+	limit int,
+	lease time.Duration,
+) ([]model.WebhookDelivery, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lease = lease
+
+	n := min(limit, len(r.pending))
+	claimed := r.pending[:n]
+	r.pending = r.pending[n:]
+
+	return claimed, nil
+}
+
+func (r *fakeDispatcherRepo) UpdateDeliveryAttempt(_ context.Context, _ repository.RepoExtension, result *model.WebhookDeliveryResult) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.results = append(r.results, *result)
+
+	return nil
+}
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+func newReceiver(t *testing.T, status int) (*httptest.Server, <-chan receivedWebhook) {
+	t.Helper()
+
+	received := make(chan receivedWebhook, 10)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		received <- receivedWebhook{header: r.Header.Clone(), body: body}
+
+		w.WriteHeader(status)
+	}))
+
+	t.Cleanup(srv.Close)
+
+	return srv, received
+}
+
+func testDelivery(url string, attempts int) model.WebhookDelivery {
+	return model.WebhookDelivery{
+		ID:             7,
+		SubscriptionID: 1,
+		EventID:        42,
+		EventType:      model.WebhookEventReviewersAssigned,
+		Payload:        json.RawMessage(`{"pull_request_id":"pr-1","reviewers":["u2","u3"]}`),
+		Attempts:       attempts,
+		EventCreatedAt: time.Date(2025, 10, 24, 10, 0, 0, 0, time.UTC),
+		URL:            url,
+		Secret:         "s3cr3t",
+	}
+}
+
+func newTestDispatcher(repo *fakeDispatcherRepo, now time.Time) *WebhookDispatcher {
+	d := NewWebhookDispatcher(zap.NewNop(), repo, DispatcherConfig{
+		MaxAttempts: 3,
+		BackoffBase: time.Second,
+		BackoffMax:  3 * time.Second,
+	})
+	d.now = func() time.Time { return now }
+
+	return d
+}
+
+func TestWebhookDispatcher_DeliversSignedPayload(t *testing.T) {
+	srv, received := newReceiver(t, http.StatusNoContent)
+
+	now := time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+	repo := &fakeDispatcherRepo{pending: []model.WebhookDelivery{testDelivery(srv.URL, 0)}}
+
+	if err := newTestDispatcher(repo, now).Tick(context.Background()); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+
+	got := <-received
+
+	if got.header.Get(WebhookEventHeader) != model.WebhookEventReviewersAssigned {
+		t.Fatalf("unexpected event header %q", got.header.Get(WebhookEventHeader))
+	}
+
+	if got.header.Get(WebhookDeliveryHeader) != "7" {
+		t.Fatalf("unexpected delivery header %q", got.header.Get(WebhookDeliveryHeader))
+	}
+
+	ts, err := strconv.ParseInt(got.header.Get(WebhookTimestampHeader), 10, 64)
+	if err != nil || ts != now.Unix() {
+		t.Fatalf("unexpected timestamp header %q", got.header.Get(WebhookTimestampHeader))
+	}
+
+	if want := SignWebhookPayload("s3cr3t", ts, got.body); got.header.Get(WebhookSignatureHeader) != want {
+		t.Fatalf("signature mismatch: got %q, want %q", got.header.Get(WebhookSignatureHeader), want)
+	}
+
+	var envelope model.WebhookEnvelope
+	if err := json.Unmarshal(got.body, &envelope); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+
+	if envelope.EventID != 42 || envelope.Event != model.WebhookEventReviewersAssigned {
+		t.Fatalf("unexpected envelope %+v", envelope)
+	}
+
+	if repo.fanOutRuns != 1 {
+		t.Fatalf("expected outbox fan-out before delivery, got %d runs", repo.fanOutRuns)
+	}
+
+	if want := defaultWebhookRequestTimeout * (defaultWebhookBatchSize + 1); repo.lease < want {
+		t.Fatalf("lease %v does not cover a full batch of %d deliveries", repo.lease, defaultWebhookBatchSize)
+	}
+
+	if len(repo.results) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(repo.results))
+	}
+
+	res := repo.results[0]
+	if res.Status != model.WebhookDeliveryDelivered || res.Attempts != 1 || res.StatusCode == nil || *res.StatusCode != http.StatusNoContent {
+		t.Fatalf("unexpected result %+v", res)
+	}
+}
+
+func TestWebhookDispatcher_RetriesWithBackoff(t *testing.T) {
+	srv, received := newReceiver(t, http.StatusInternalServerError)
+
+	now := time.Date(2025, 10, 24, 12, 0, 0, 0, time.UTC)
+	repo := &fakeDispatcherRepo{pending: []model.WebhookDelivery{testDelivery(srv.URL, 1)}}
+
+	if err := newTestDispatcher(repo, now).Tick(context.Background()); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+
+	<-received
+
+	res := repo.results[0]
+	if res.Status != model.WebhookDeliveryPending || res.Attempts != 2 {
+		t.Fatalf("unexpected result %+v", res)
+	}
+
+	if want := now.Add(2 * time.Second); !res.NextAttemptAt.Equal(want) {
+		t.Fatalf("next attempt at %v, want %v", res.NextAttemptAt, want)
+	}
+
+	if res.Error == "" {
+		t.Fatal("expected error to be recorded")
+	}
+}
+
+func TestWebhookDispatcher_DeadLettersAfterMaxAttempts(t *testing.T) {
+	srv, received := newReceiver(t, http.StatusBadGateway)
+
+	repo := &fakeDispatcherRepo{pending: []model.WebhookDelivery{testDelivery(srv.URL, 2)}}
+
+	if err := newTestDispatcher(repo, time.Now()).Tick(context.Background()); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+
+	<-received
+
+	res := repo.results[0]
+	if res.Status != model.WebhookDeliveryDead || res.Attempts != 3 {
+		t.Fatalf("unexpected result %+v", res)
+	}
+
+	if res.StatusCode == nil || *res.StatusCode != http.StatusBadGateway {
+		t.Fatalf("unexpected status code %v", res.StatusCode)
+	}
+}
+
+func TestWebhookDispatcher_UnreachableReceiver(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	url := srv.URL
+	srv.Close()
+
+	repo := &fakeDispatcherRepo{pending: []model.WebhookDelivery{testDelivery(url, 0)}}
+
+	if err := newTestDispatcher(repo, time.Now()).Tick(context.Background()); err != nil {
+		t.Fatalf("tick: %v", err)
+	}
+
+	res := repo.results[0]
+	if res.Status != model.WebhookDeliveryPending || res.StatusCode != nil || res.Error == "" {
+		t.Fatalf("unexpected result %+v", res)
+	}
+}
+
+func TestWebhookDispatcher_Backoff(t *testing.T) {
+	d := newTestDispatcher(&fakeDispatcherRepo{}, time.Now())
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: time.Second},
+		{attempt: 2, want: 2 * time.Second},
+		{attempt: 3, want: 3 * time.Second},
+		{attempt: 10, want: 3 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := d.backoff(tt.attempt); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func newTestExporter(t *testing.T) *tracetest.InMemoryExporter {
+	t.Helper()
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+
+	t.Cleanup(func() {
+		otel.SetTracerProvider(prev)
+		_ = provider.Shutdown(context.Background())
+	})
+
+	return exporter
+}
+
+func spanAttr(span tracetest.SpanStub, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+
+	return attribute.Value{}, false
+}
+
+func TestQueryTracer(t *testing.T) {
+	exporter := newTestExporter(t)
+	tracer := NewQueryTracer()
+
+	ctx, parent := Start(context.Background(), "PullRequestService.Reassign")
+
+	queryCtx := tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "\n\t\tselect reviewer_id from pr_reviewers where pull_request_id = $1;\n"})
+	tracer.TraceQueryEnd(queryCtx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 2")})
+
+	failedCtx := tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "UPDATE users SET is_active = $1"})
+	tracer.TraceQueryEnd(failedCtx, nil, pgx.TraceQueryEndData{Err: errors.New("deadlock detected")})
+
+	parent.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d", len(spans))
+	}
+
+	query, failed, service := spans[0], spans[1], spans[2]
+
+	if query.Name != "db SELECT" || query.SpanKind != trace.SpanKindClient {
+		t.Fatalf("unexpected query span %q kind %v", query.Name, query.SpanKind)
+	}
+
+	if query.Parent.SpanID() != service.SpanContext.SpanID() || failed.Parent.SpanID() != service.SpanContext.SpanID() {
+		t.Fatal("query spans are not children of the service span")
+	}
+
+	if v, _ := spanAttr(query, "db.query.text"); v.AsString() != "select reviewer_id from pr_reviewers where pull_request_id = $1;" {
+		t.Fatalf("unexpected query text %q", v.AsString())
+	}
+
+	if v, _ := spanAttr(query, "db.response.returned_rows"); v.AsInt64() != 2 {
+		t.Fatalf("unexpected returned rows %d", v.AsInt64())
+	}
+
+	if failed.Name != "db UPDATE" || failed.Status.Code != codes.Error || len(failed.Events) == 0 {
+		t.Fatalf("failed query span not marked as error: %+v", failed.Status)
+	}
+}
+
+func TestEnd(t *testing.T) {
+	exporter := newTestExporter(t)
+
+	traced := func(fail bool) (err error) {
+		_, span := Start(context.Background(), "PullRequestService.Reassign")
+		defer End(span, &err)
+
+		if fail {
+			return errors.New("no active replacement candidate")
+		}
+
+		return nil
+	}
+
+	_ = traced(false)
+	_ = traced(true)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+
+	ok, failed := spans[0], spans[1]
+
+	if ok.Status.Code == codes.Error || len(ok.Events) != 0 {
+		t.Fatalf("successful span marked as error: %+v", ok.Status)
+	}
+
+	if failed.Status.Code != codes.Error || failed.Status.Description != "no active replacement candidate" || len(failed.Events) == 0 {
+		t.Fatalf("failed span not marked as error: %+v", failed.Status)
+	}
+}
+
+func TestLogFields(t *testing.T) {
+	newTestExporter(t)
+
+	if fields := LogFields(context.Background()); fields != nil {
+		t.Fatalf("expected no fields without a span, got %v", fields)
+	}
+
+	ctx, span := Start(context.Background(), "test")
+	defer span.End()
+
+	fields := LogFields(ctx)
+	if len(fields) != 2 || fields[0].Key != "trace_id" || fields[0].String != span.SpanContext().TraceID().String() {
+		t.Fatalf("unexpected fields %v", fields)
+	}
+}
@@ -0,0 +1,3 @@
+-- 000006_add_teams_reviewers_count.down.sql
+
+ALTER TABLE teams DROP COLUMN IF EXISTS reviewers_count;
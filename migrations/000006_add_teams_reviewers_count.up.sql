@@ -0,0 +1,5 @@
+-- 000006_add_teams_reviewers_count.up.sql
+
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS reviewers_count INTEGER NOT NULL DEFAULT 2
+        CHECK (reviewers_count > 0);
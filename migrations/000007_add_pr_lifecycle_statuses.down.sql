@@ -0,0 +1,15 @@
+-- 000007_add_pr_lifecycle_statuses.down.sql
+
+ALTER TABLE pull_requests ALTER COLUMN status DROP DEFAULT;
+
+ALTER TYPE pr_status RENAME TO pr_status_old;
+
+CREATE TYPE pr_status AS ENUM ('OPEN','MERGED');
+
+ALTER TABLE pull_requests
+    ALTER COLUMN status TYPE pr_status
+        USING (CASE WHEN status = 'MERGED' THEN 'MERGED' ELSE 'OPEN' END)::pr_status;
+
+ALTER TABLE pull_requests ALTER COLUMN status SET DEFAULT 'OPEN';
+
+DROP TYPE pr_status_old;
@@ -0,0 +1,5 @@
+-- 000007_add_pr_lifecycle_statuses.up.sql
+
+ALTER TYPE pr_status ADD VALUE IF NOT EXISTS 'DRAFT';
+ALTER TYPE pr_status ADD VALUE IF NOT EXISTS 'CLOSED';
+ALTER TYPE pr_status ADD VALUE IF NOT EXISTS 'REOPENED';
@@ -0,0 +1,7 @@
+-- 000008_add_pr_reviews_table.down.sql
+
+ALTER TABLE teams DROP COLUMN IF EXISTS required_approvals;
+
+DROP TABLE IF EXISTS pr_reviews;
+
+DROP TYPE IF EXISTS review_verdict;
@@ -0,0 +1,18 @@
+-- 000008_add_pr_reviews_table.up.sql
+
+CREATE TYPE review_verdict AS ENUM ('APPROVED','CHANGES_REQUESTED','COMMENTED');
+
+CREATE TABLE IF NOT EXISTS pr_reviews (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    reviewer_id TEXT NOT NULL REFERENCES users(id),
+    verdict review_verdict NOT NULL,
+    comment TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS pr_reviews_pull_request_id_idx ON pr_reviews (pull_request_id, reviewer_id, created_at DESC);
+
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS required_approvals INTEGER NOT NULL DEFAULT 0
+        CHECK (required_approvals >= 0);
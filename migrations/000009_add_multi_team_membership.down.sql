@@ -0,0 +1,7 @@
+-- 000009_add_multi_team_membership.down.sql
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS team_id;
+
+DROP INDEX IF EXISTS team_lnk_team_id_idx;
+
+ALTER TABLE team_lnk DROP CONSTRAINT IF EXISTS team_lnk_pkey;
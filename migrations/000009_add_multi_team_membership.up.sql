@@ -0,0 +1,22 @@
+-- 000009_add_multi_team_membership.up.sql
+
+DELETE FROM team_lnk a
+USING team_lnk b
+WHERE a.ctid < b.ctid
+  AND a.user_id = b.user_id
+  AND a.team_id = b.team_id;
+
+ALTER TABLE team_lnk ADD CONSTRAINT team_lnk_pkey PRIMARY KEY (user_id, team_id);
+
+CREATE INDEX IF NOT EXISTS team_lnk_team_id_idx ON team_lnk (team_id);
+
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS team_id INTEGER NULL REFERENCES teams(id) ON DELETE SET NULL;
+
+UPDATE pull_requests pr
+SET team_id = (
+    SELECT MIN(tl.team_id)
+    FROM team_lnk tl
+    WHERE tl.user_id = pr.author_id
+)
+WHERE pr.team_id IS NULL;
@@ -0,0 +1,7 @@
+-- 000010_add_audit_events_table.down.sql
+
+DROP TRIGGER IF EXISTS audit_events_no_update ON audit_events;
+
+DROP FUNCTION IF EXISTS audit_events_append_only();
+
+DROP TABLE IF EXISTS audit_events;
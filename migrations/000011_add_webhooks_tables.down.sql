@@ -0,0 +1,9 @@
+-- 000011_add_webhooks_tables.down.sql
+
+DROP TABLE IF EXISTS webhook_deliveries;
+
+DROP TYPE IF EXISTS webhook_delivery_status;
+
+DROP TABLE IF EXISTS outbox_events;
+
+DROP TABLE IF EXISTS webhook_subscriptions;
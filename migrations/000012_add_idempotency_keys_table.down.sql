@@ -0,0 +1,3 @@
+-- 000012_add_idempotency_keys_table.down.sql
+
+DROP TABLE IF EXISTS idempotency_keys;
@@ -0,0 +1,3 @@
+-- 000013_add_pr_merged_by.down.sql
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS merged_by;
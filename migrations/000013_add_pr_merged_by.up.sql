@@ -0,0 +1,3 @@
+-- 000013_add_pr_merged_by.up.sql
+
+ALTER TABLE pull_requests ADD COLUMN IF NOT EXISTS merged_by TEXT NULL;
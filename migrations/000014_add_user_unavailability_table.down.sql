@@ -0,0 +1,3 @@
+-- 000014_add_user_unavailability_table.down.sql
+
+DROP TABLE IF EXISTS user_unavailability;
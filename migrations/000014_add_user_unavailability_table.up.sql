@@ -0,0 +1,15 @@
+-- 000014_add_user_unavailability_table.up.sql
+
+CREATE TABLE IF NOT EXISTS user_unavailability (
+    id BIGSERIAL PRIMARY KEY,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    reassigned_at TIMESTAMP WITH TIME ZONE NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS user_unavailability_user_idx ON user_unavailability (user_id, ends_at);
+CREATE INDEX IF NOT EXISTS user_unavailability_pending_idx ON user_unavailability (starts_at) WHERE reassigned_at IS NULL;
@@ -0,0 +1,9 @@
+-- 000015_add_review_capacity.up.sql
+
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS max_open_reviews INTEGER NULL
+        CHECK (max_open_reviews >= 0);
+
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS default_max_open_reviews INTEGER NULL
+        CHECK (default_max_open_reviews >= 0);
@@ -0,0 +1,5 @@
+-- 000016_add_code_owner_rules.down.sql
+
+ALTER TABLE pull_requests DROP COLUMN IF EXISTS changed_paths;
+
+DROP TABLE IF EXISTS code_owner_rules;
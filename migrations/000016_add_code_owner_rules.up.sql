@@ -0,0 +1,17 @@
+-- 000016_add_code_owner_rules.up.sql
+
+CREATE TABLE IF NOT EXISTS code_owner_rules (
+    id BIGSERIAL PRIMARY KEY,
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    pattern TEXT NOT NULL,
+    owner_users TEXT[] NOT NULL DEFAULT '{}',
+    owner_teams TEXT[] NOT NULL DEFAULT '{}',
+    required BOOLEAN NOT NULL DEFAULT false,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    CHECK (cardinality(owner_users) + cardinality(owner_teams) > 0)
+);
+
+CREATE INDEX IF NOT EXISTS code_owner_rules_team_idx ON code_owner_rules (team_id, id);
+
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS changed_paths TEXT[] NOT NULL DEFAULT '{}';
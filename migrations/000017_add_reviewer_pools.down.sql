@@ -0,0 +1,7 @@
+-- 000017_add_reviewer_pools.down.sql
+
+DROP TABLE IF EXISTS team_reviewer_pools;
+
+DROP TABLE IF EXISTS reviewer_pool_members;
+
+DROP TABLE IF EXISTS reviewer_pools;
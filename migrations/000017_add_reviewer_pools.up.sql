@@ -0,0 +1,23 @@
+-- 000017_add_reviewer_pools.up.sql
+
+CREATE TABLE IF NOT EXISTS reviewer_pools (
+    id SERIAL PRIMARY KEY,
+    pool_name TEXT NOT NULL UNIQUE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS reviewer_pool_members (
+    pool_id INTEGER NOT NULL REFERENCES reviewer_pools(id) ON DELETE CASCADE,
+    user_id TEXT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    PRIMARY KEY (pool_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS team_reviewer_pools (
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    pool_id INTEGER NOT NULL REFERENCES reviewer_pools(id) ON DELETE CASCADE,
+    mode TEXT NOT NULL CHECK (mode IN ('ALWAYS', 'FALLBACK')),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    PRIMARY KEY (team_id, pool_id)
+);
+
+CREATE INDEX IF NOT EXISTS team_reviewer_pools_pool_idx ON team_reviewer_pools (pool_id);
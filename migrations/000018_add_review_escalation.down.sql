@@ -0,0 +1,7 @@
+-- 000018_add_review_escalation.down.sql
+
+DROP TABLE IF EXISTS review_escalations;
+
+ALTER TABLE teams
+    DROP COLUMN IF EXISTS stale_review_action,
+    DROP COLUMN IF EXISTS review_sla_minutes;
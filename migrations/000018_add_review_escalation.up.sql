@@ -0,0 +1,21 @@
+-- 000018_add_review_escalation.up.sql
+
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS review_sla_minutes INTEGER NULL
+        CHECK (review_sla_minutes > 0),
+    ADD COLUMN IF NOT EXISTS stale_review_action TEXT NOT NULL DEFAULT 'REASSIGN'
+        CHECK (stale_review_action IN ('REASSIGN', 'ADD_REVIEWER'));
+
+CREATE TABLE IF NOT EXISTS review_escalations (
+    id BIGSERIAL PRIMARY KEY,
+    pull_request_id TEXT NOT NULL REFERENCES pull_requests(pull_request_id) ON DELETE CASCADE,
+    team_id INTEGER NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+    reviewer_id TEXT NOT NULL,
+    assigned_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    action TEXT NOT NULL CHECK (action IN ('REASSIGN', 'ADD_REVIEWER')),
+    new_reviewer_id TEXT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    UNIQUE (pull_request_id, reviewer_id, assigned_at)
+);
+
+CREATE INDEX IF NOT EXISTS review_escalations_team_idx ON review_escalations (team_id, created_at);
@@ -0,0 +1,5 @@
+-- 000019_add_auth.down.sql
+
+DROP TABLE IF EXISTS api_tokens;
+
+ALTER TABLE users DROP COLUMN IF EXISTS role;
@@ -0,0 +1,12 @@
+-- 000020_scope_idempotency_keys_by_actor.down.sql
+
+DELETE FROM idempotency_keys;
+
+ALTER TABLE idempotency_keys
+    DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+
+ALTER TABLE idempotency_keys
+    DROP COLUMN IF EXISTS actor_id;
+
+ALTER TABLE idempotency_keys
+    ADD PRIMARY KEY (idempotency_key, route);
@@ -0,0 +1,10 @@
+-- 000020_scope_idempotency_keys_by_actor.up.sql
+
+ALTER TABLE idempotency_keys
+    ADD COLUMN IF NOT EXISTS actor_id TEXT NOT NULL DEFAULT '';
+
+ALTER TABLE idempotency_keys
+    DROP CONSTRAINT IF EXISTS idempotency_keys_pkey;
+
+ALTER TABLE idempotency_keys
+    ADD PRIMARY KEY (idempotency_key, route, actor_id);
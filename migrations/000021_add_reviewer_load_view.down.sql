@@ -0,0 +1,3 @@
+-- 000021_add_reviewer_load_view.down.sql
+
+DROP VIEW IF EXISTS reviewer_load;
@@ -0,0 +1,25 @@
+-- 000021_add_reviewer_load_view.up.sql
+
+CREATE OR REPLACE VIEW reviewer_load AS
+SELECT u.id AS user_id,
+       COUNT(DISTINCT pr.pull_request_id) AS open_reviews,
+       COALESCE(
+           u.max_open_reviews,
+           (
+               SELECT MIN(t.default_max_open_reviews)
+               FROM team_lnk tl
+               JOIN teams t ON t.id = tl.team_id
+               WHERE tl.user_id = u.id
+           )
+       ) AS max_open_reviews,
+       u.is_active AND NOT EXISTS (
+           SELECT 1
+           FROM user_unavailability ua
+           WHERE ua.user_id = u.id
+             AND ua.starts_at <= now()
+             AND ua.ends_at > now()
+       ) AS available
+FROM users u
+LEFT JOIN pr_reviewers prr ON prr.reviewer_id = u.id
+LEFT JOIN pull_requests pr ON pr.pull_request_id = prr.pull_request_id AND pr.status IN ('OPEN', 'REOPENED')
+GROUP BY u.id;
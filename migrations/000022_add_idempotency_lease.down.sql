@@ -0,0 +1,4 @@
+-- 000022_add_idempotency_lease.down.sql
+
+ALTER TABLE idempotency_keys
+    DROP COLUMN IF EXISTS locked_until;
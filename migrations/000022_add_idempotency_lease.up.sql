@@ -0,0 +1,4 @@
+-- 000022_add_idempotency_lease.up.sql
+
+ALTER TABLE idempotency_keys
+    ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ NOT NULL DEFAULT now();
@@ -9,6 +9,7 @@ import (
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -32,6 +33,7 @@ type Config struct {
 	MaxConns  int32
 	MinConns  int32
 	Migration Migration
+	Tracer    pgx.QueryTracer
 }
 
 type Migration struct {
@@ -63,6 +65,7 @@ func New(cfg *Config) (postgresDB Postgres, err error) {
 	config.MinConns = cfg.MinConns
 	config.MaxConnLifetime = MaxConnLifetime
 	config.MaxConnIdleTime = MaxConnIdleTime
+	config.ConnConfig.Tracer = cfg.Tracer
 
 	pool, err := pgxpool.NewWithConfig(context.Background(), config)
 	if err != nil {